@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/jdstrand/language-checker/pkg/baseline"
+	"github.com/jdstrand/language-checker/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+var baselineOutputFile string
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage baselines of existing findings",
+	Long: `
+A baseline is a file containing existing findings, which are not reported when
+running language-checker with --baseline. This makes it possible to adopt
+language-checker incrementally, while still blocking new findings.`,
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [globs ...]",
+	Short: "Create a baseline from all current findings",
+	RunE:  baselineCreateRunE,
+}
+
+func baselineCreateRunE(cmd *cobra.Command, args []string) error {
+	setDebugLogLevel()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	fs, err := gitRootDir()
+	if err != nil {
+		return err
+	}
+
+	p, err := newParser(cfg)
+	if err != nil {
+		return err
+	}
+	// an existing baseline always matches on its own rules
+	p.SkipFiles = append(p.SkipFiles, baselineOutputFile)
+
+	// write to a buffer first, so an existing baseline isn't truncated if parsing fails
+	buf := new(bytes.Buffer)
+	w := baseline.NewWriter(buf, fs.Root())
+
+	ctx, stop := signalContext(cmd)
+	defer stop()
+	if _, err := p.ParsePathsContext(ctx, w, parseArgs(args)...); err != nil {
+		return fmt.Errorf("baseline not written, not all files were checked: %w", err)
+	}
+
+	if err := os.WriteFile(baselineOutputFile, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output.Stdout, "Wrote %d findings to %s\n", w.Len(), baselineOutputFile)
+	return nil
+}
+
+func init() {
+	baselineCreateCmd.Flags().StringVarP(&baselineOutputFile, "file", "f", baseline.DefaultFilename, "Baseline file to write")
+	baselineCmd.AddCommand(baselineCreateCmd)
+	rootCmd.AddCommand(baselineCmd)
+}
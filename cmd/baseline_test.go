@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseline(t *testing.T) {
+	origStdout := output.Stdout
+	t.Cleanup(func() {
+		output.Stdout = origStdout
+		baselineFile = ""
+		baselineOutputFile = ""
+	})
+
+	dir := t.TempDir()
+	f := filepath.Join(dir, "file.txt")
+	assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+	buf := new(bytes.Buffer)
+	output.Stdout = buf
+	baselineOutputFile = filepath.Join(dir, "baseline.yaml")
+	assert.NoError(t, baselineCreateRunE(new(cobra.Command), []string{f}))
+	assert.Equal(t, "Wrote 1 findings to "+baselineOutputFile+"\n", buf.String())
+
+	// existing findings are not reported
+	buf.Reset()
+	baselineFile = baselineOutputFile
+	assert.NoError(t, rootRunE(new(cobra.Command), []string{f}))
+	assert.Equal(t, "No findings found.\n", buf.String())
+
+	// new findings are reported
+	assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\nand a new whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+	buf.Reset()
+	assert.NoError(t, rootRunE(new(cobra.Command), []string{f}))
+	assert.Contains(t, buf.String(), ":2:")
+	assert.NotContains(t, buf.String(), ":1:")
+
+	// the baseline itself is never checked, even with --no-ignore
+	noIgnore = true
+	t.Cleanup(func() {
+		noIgnore = false
+	})
+	buf.Reset()
+	assert.NoError(t, rootRunE(new(cobra.Command), []string{dir}))
+	assert.Contains(t, buf.String(), "file.txt")
+	assert.NotContains(t, buf.String(), "baseline.yaml")
+
+	buf.Reset()
+	assert.NoError(t, baselineCreateRunE(new(cobra.Command), []string{dir}))
+	assert.Equal(t, "Wrote 2 findings to "+baselineOutputFile+"\n", buf.String())
+
+	baselineFile = filepath.Join(dir, "missing.yaml")
+	assert.Error(t, rootRunE(new(cobra.Command), []string{f}))
+}
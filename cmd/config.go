@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jdstrand/language-checker/pkg/config"
+	"github.com/jdstrand/language-checker/pkg/output"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrNoConfigFile is returned when there is no config file to validate
+var ErrNoConfigFile = errors.New("no config file found, use --config or pass a config file")
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage config files",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Strictly check a config file for mistakes",
+	Long: `
+Strictly check a config file, which is --config or the default config file if no
+file is given. Unlike when the config is loaded to check files, unknown fields,
+duplicate rule names, empty terms, invalid severities, unknown categories and terms
+that are also terms of other rules are all reported, with the line they are on.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: configValidateRunE,
+}
+
+func configValidateRunE(cmd *cobra.Command, args []string) error {
+	setDebugLogLevel()
+	setRemoteOptions()
+
+	file := viper.ConfigFileUsed()
+	if len(args) > 0 {
+		file = args[0]
+	}
+	if file == "" {
+		return ErrNoConfigFile
+	}
+
+	problems, err := config.Validate(file, disableDefaultRules)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintln(output.Stdout, p)
+	}
+	if len(problems) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d problems in %s", len(problems), file)
+	}
+
+	fmt.Fprintf(output.Stdout, "No problems found in %s.\n", file)
+	return nil
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
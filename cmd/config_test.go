@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	origStdout := output.Stdout
+	t.Cleanup(func() {
+		output.Stdout = origStdout
+	})
+
+	buf := new(bytes.Buffer)
+	output.Stdout = buf
+	assert.NoError(t, configValidateRunE(new(cobra.Command), []string{"../pkg/config/testdata/good.yaml"}))
+	assert.Equal(t, "No problems found in ../pkg/config/testdata/good.yaml.\n", buf.String())
+
+	buf.Reset()
+	err := configValidateRunE(new(cobra.Command), []string{"../pkg/config/testdata/validate/invalid.yaml"})
+	assert.ErrorContains(t, err, "problems in ../pkg/config/testdata/validate/invalid.yaml")
+	assert.Contains(t, buf.String(), "../pkg/config/testdata/validate/invalid.yaml:")
+
+	assert.Error(t, configValidateRunE(new(cobra.Command), []string{"../testdata/missing.yaml"}))
+}
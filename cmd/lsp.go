@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/jdstrand/language-checker/pkg/lsp"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server over stdio",
+	Long: `
+Run a Language Server Protocol server over stdin and stdout, so editors can show
+findings as diagnostics while you type, with quick fixes for each alternative.`,
+	Args: cobra.NoArgs,
+	RunE: lspRunE,
+}
+
+func lspRunE(cmd *cobra.Command, args []string) error {
+	// stdout is used for the protocol, so logs have to go to stderr
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	setDebugLogLevel()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	p, err := newParser(cfg)
+	if err != nil {
+		return err
+	}
+
+	return lsp.NewServer(p, getVersion("short"), os.Stdin, os.Stdout).Run()
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
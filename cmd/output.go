@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+	"github.com/jdstrand/language-checker/pkg/printer"
+)
+
+// ErrMultipleStdoutOutputs is returned when more than one output would be written to stdout
+var ErrMultipleStdoutOutputs = errors.New("only one `--output` can be written to stdout, write the others to files with type=path")
+
+// outputs are the printers of the --output flags, and the files that they write to
+type outputs struct {
+	printers []printer.Printer
+	// stdout is the printer that writes to stdout, or nil if every output is written to a file
+	stdout printer.Printer
+	files  []*outputFile
+}
+
+// outputFile is the file that an output is written to.
+// Most printers don't return the errors of their writes, so the first one is kept to be returned by close.
+type outputFile struct {
+	name string
+	path string
+	file *os.File
+	err  error
+}
+
+func (f *outputFile) Write(b []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	n, err := f.file.Write(b)
+	if err != nil {
+		f.err = fmt.Errorf("unable to write %s output to %s: %w", f.name, f.path, err)
+	}
+	return n, f.err
+}
+
+// parseOutput splits an --output flag into the output type and the path of the file to write it to,
+// such as sarif=report.sarif. The path is empty if the output is written to stdout.
+func parseOutput(s string) (name, path string) {
+	name, path, _ = strings.Cut(s, "=")
+	return name, path
+}
+
+// newOutputs returns the outputs of the --output flags.
+// The files they are written to are only opened once every output is valid.
+func newOutputs(names []string, o printer.Options) (*outputs, error) {
+	if len(names) == 0 {
+		names = []string{printer.OutFormatText}
+	}
+
+	outs := &outputs{}
+	paths := map[string]string{}
+	for _, n := range names {
+		name, path := parseOutput(n)
+
+		var w io.Writer = output.Stdout
+		opts := o
+		if path != "" {
+			if other, ok := paths[filepath.Clean(path)]; ok {
+				return nil, fmt.Errorf("%s and %s outputs are both written to %s", other, name, path)
+			}
+			paths[filepath.Clean(path)] = name
+
+			f := &outputFile{name: name, path: path}
+			outs.files = append(outs.files, f)
+			w = f
+			opts.DisableColor = true
+		} else if outs.stdout != nil {
+			return nil, ErrMultipleStdoutOutputs
+		}
+
+		p, err := printer.NewPrinterWithOptions(name, w, opts)
+		if err != nil {
+			return nil, err
+		}
+		outs.printers = append(outs.printers, p)
+		if path == "" {
+			outs.stdout = p
+		}
+	}
+
+	if err := outs.open(); err != nil {
+		return nil, err
+	}
+	return outs, nil
+}
+
+// open opens the files that the outputs are written to. Existing files are only truncated
+// once every file is opened, and if any file can't be opened, only the files it created are removed.
+func (o *outputs) open() error {
+	var created []string
+	for _, f := range o.files {
+		file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+		if err == nil {
+			created = append(created, f.path)
+		} else if errors.Is(err, os.ErrExist) {
+			file, err = os.OpenFile(f.path, os.O_WRONLY, 0)
+		}
+		if err != nil {
+			o.close()
+			for _, path := range created {
+				os.Remove(path)
+			}
+			return err
+		}
+		f.file = file
+	}
+
+	for _, f := range o.files {
+		// files such as /dev/stderr can't be truncated
+		if info, err := f.file.Stat(); err == nil && info.Mode().IsRegular() {
+			if err := f.file.Truncate(0); err != nil {
+				o.close()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printer returns the printer that prints to every output
+func (o *outputs) printer() printer.Printer {
+	if len(o.printers) == 1 {
+		return o.printers[0]
+	}
+	return printer.NewMulti(o.printers...)
+}
+
+// close closes the files that the outputs are written to, returning the errors of
+// the printers that failed to print the results, and of writing or closing the files
+func (o *outputs) close() error {
+	var errs []error
+	for _, p := range o.printers {
+		if err := printer.Err(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, f := range o.files {
+		if f.file == nil {
+			continue
+		}
+		if f.err != nil {
+			errs = append(errs, f.err)
+		}
+		if err := f.file.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	o.files = nil
+	return errors.Join(errs...)
+}
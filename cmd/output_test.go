@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+	"github.com/jdstrand/language-checker/pkg/printer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutput(t *testing.T) {
+	name, path := parseOutput("text")
+	assert.Equal(t, "text", name)
+	assert.Empty(t, path)
+
+	name, path = parseOutput("sarif=reports/report.sarif")
+	assert.Equal(t, "sarif", name)
+	assert.Equal(t, "reports/report.sarif", path)
+}
+
+func TestNewOutputs(t *testing.T) {
+	origStdout := output.Stdout
+	t.Cleanup(func() {
+		output.Stdout = origStdout
+	})
+	output.Stdout = new(bytes.Buffer)
+
+	t.Run("default", func(t *testing.T) {
+		outs, err := newOutputs(nil, printer.Options{})
+		assert.NoError(t, err)
+		assert.IsType(t, &printer.Text{}, outs.printer())
+		assert.Equal(t, outs.stdout, outs.printer())
+		assert.NoError(t, outs.close())
+	})
+
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		outs, err := newOutputs([]string{"text", "sarif=" + filepath.Join(dir, "report.sarif"), "junit=" + filepath.Join(dir, "junit.xml")}, printer.Options{})
+		assert.NoError(t, err)
+		assert.IsType(t, &printer.Multi{}, outs.printer())
+		assert.IsType(t, &printer.Text{}, outs.stdout)
+		assert.Len(t, outs.files, 2)
+		assert.FileExists(t, filepath.Join(dir, "report.sarif"))
+		assert.FileExists(t, filepath.Join(dir, "junit.xml"))
+		assert.NoError(t, outs.close())
+	})
+
+	t.Run("only files", func(t *testing.T) {
+		outs, err := newOutputs([]string{"json=" + filepath.Join(t.TempDir(), "out.json")}, printer.Options{})
+		assert.NoError(t, err)
+		assert.IsType(t, &printer.JSON{}, outs.printer())
+		assert.Nil(t, outs.stdout)
+		assert.NoError(t, outs.close())
+	})
+
+	t.Run("more than one stdout output", func(t *testing.T) {
+		_, err := newOutputs([]string{"text", "json"}, printer.Options{})
+		assert.ErrorIs(t, err, ErrMultipleStdoutOutputs)
+	})
+
+	t.Run("same file", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := newOutputs([]string{"json=" + filepath.Join(dir, "out"), "sarif=" + dir + "/./out"}, printer.Options{})
+		assert.EqualError(t, err, "json and sarif outputs are both written to "+dir+"/./out")
+	})
+
+	t.Run("invalid output doesn't create or truncate files", func(t *testing.T) {
+		dir := t.TempDir()
+		existing := filepath.Join(dir, "existing.json")
+		assert.NoError(t, os.WriteFile(existing, []byte("{}"), 0o644))
+
+		tests := []struct {
+			desc     string
+			names    []string
+			expected string
+		}{
+			{"invalid printer", []string{"json=" + existing, "sarif=" + filepath.Join(dir, "out.sarif"), "foo=" + filepath.Join(dir, "out.foo")}, "foo is not a valid printer type"},
+			{"no template", []string{"json=" + existing, "template=" + filepath.Join(dir, "out.txt")}, printer.ErrNoTemplate.Error()},
+			{"more than one stdout output", []string{"json=" + existing, "text", "json"}, ErrMultipleStdoutOutputs.Error()},
+			{"unable to open file", []string{"json=" + existing, "sarif=" + filepath.Join(dir, "out.sarif"), "junit=" + filepath.Join(dir, "missing", "junit.xml")}, "open " + filepath.Join(dir, "missing", "junit.xml") + ": no such file or directory"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.desc, func(t *testing.T) {
+				_, err := newOutputs(tc.names, printer.Options{})
+				assert.EqualError(t, err, tc.expected)
+
+				entries, err := os.ReadDir(dir)
+				assert.NoError(t, err)
+				if assert.Len(t, entries, 1) {
+					assert.Equal(t, "existing.json", entries[0].Name())
+				}
+				b, err := os.ReadFile(existing)
+				assert.NoError(t, err)
+				assert.Equal(t, "{}", string(b))
+			})
+		}
+	})
+
+	t.Run("existing files are truncated", func(t *testing.T) {
+		existing := filepath.Join(t.TempDir(), "out.json")
+		assert.NoError(t, os.WriteFile(existing, []byte("previous output"), 0o644))
+
+		outs, err := newOutputs([]string{"json=" + existing}, printer.Options{})
+		assert.NoError(t, err)
+		assert.NoError(t, outs.close())
+		b, err := os.ReadFile(existing)
+		assert.NoError(t, err)
+		assert.Empty(t, b)
+	})
+}
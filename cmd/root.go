@@ -23,19 +23,25 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/jdstrand/language-checker/pkg/baseline"
 	"github.com/jdstrand/language-checker/pkg/config"
+	"github.com/jdstrand/language-checker/pkg/fixer"
+	"github.com/jdstrand/language-checker/pkg/gitdiff"
 	"github.com/jdstrand/language-checker/pkg/ignore"
 	"github.com/jdstrand/language-checker/pkg/output"
 	"github.com/jdstrand/language-checker/pkg/parser"
 	"github.com/jdstrand/language-checker/pkg/printer"
 
+	"github.com/go-git/go-billy/v5"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -49,9 +55,23 @@ var (
 	cfgFile             string
 	debug               bool
 	stdin               bool
-	outputName          string
+	outputNames         []string
 	noIgnore            bool
 	disableDefaultRules bool
+	fix                 bool
+	fixDryRun           bool
+	diffBase            string
+	staged              bool
+	baselineFile        string
+	jobs                int
+	failFast            bool
+	unsorted            bool
+	reportUnusedIgnores bool
+	cacheDir            string
+	configTimeout       time.Duration
+	offline             bool
+	includePassing      bool
+	templateFile        string
 
 	// Version is populated by goreleaser during build
 	// Version...
@@ -72,16 +92,25 @@ language and provide suggestions for alternatives. Rules can be customized
 to suit your needs.
 
 Provide a list file globs for files you'd like to check.`,
+	// paths are arguments of the root command, and must not be mistaken for subcommands
+	Args: cobra.ArbitraryArgs,
 	RunE: rootRunE,
 }
 
 var ErrNoRulesEnabled = errors.New("no rules enabled: either configure rules in your config file or remove the `--disable-default-rules` flag")
 
+var ErrFixWithStdin = errors.New("`--fix` and `--fix-dry-run` cannot be used with `--stdin`")
+
+var ErrDiffWithStdin = errors.New("`--diff` and `--staged` cannot be used with `--stdin`")
+
 func rootRunE(cmd *cobra.Command, args []string) error {
 	setDebugLogLevel()
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
 	log.Debug().Msg(getVersion("default"))
+	if _, ok := os.LookupEnv("WORKER_POOL_COUNT"); ok {
+		log.Warn().Msg("WORKER_POOL_COUNT is deprecated and has no effect, use --jobs instead")
+	}
 
 	start := time.Now()
 	defer func() {
@@ -90,47 +119,98 @@ func rootRunE(cmd *cobra.Command, args []string) error {
 			Msg("language-checker completed")
 	}()
 
-	cfg, err := config.NewConfig(viper.ConfigFileUsed(), disableDefaultRules)
-	if err != nil {
-		return err
+	if (fix || fixDryRun) && stdin {
+		return ErrFixWithStdin
+	}
+	if (diffBase != "" || staged) && stdin {
+		return ErrDiffWithStdin
 	}
 
-	if len(cfg.Rules) == 0 {
-		return ErrNoRulesEnabled
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
-	var ignorer *ignore.Ignore
-	if !noIgnore {
-		cwd, err := os.Getwd()
+	// paths in the baseline are relative to the git root
+	var baselineRoot string
+	if baselineFile != "" {
+		fs, err := gitRootDir()
 		if err != nil {
 			return err
 		}
-		fs, err := ignore.GetRootGitDir(cwd)
+		baselineRoot = fs.Root()
+	}
+
+	p, err := newParser(cfg)
+	if err != nil {
+		return err
+	}
+
+	if baselineFile != "" {
+		p.Baseline, err = baseline.Load(baselineFile, baselineRoot)
 		if err != nil {
 			return err
 		}
-		ignorer, err = ignore.NewIgnore(fs, cfg.IgnoreFiles)
+		// the baseline always matches on its own rules
+		p.SkipFiles = append(p.SkipFiles, baselineFile)
+	}
+
+	// stdoutPrinter is the printer that prints to stdout, which decides if the success exit message is printed
+	var print, stdoutPrinter printer.Printer
+	var fx *fixer.Fixer
+	outs := &outputs{}
+	if fix || fixDryRun {
+		fx = fixer.NewFixer(output.Stdout, fixDryRun)
+		print, stdoutPrinter = fx, fx
+	} else {
+		outs, err = newOutputs(outputNames, printer.Options{
+			IncludePassing: includePassing,
+			Template:       templateFile,
+		})
 		if err != nil {
 			return err
 		}
+		print = outs.printer()
+		stdoutPrinter = outs.stdout
 	}
-	p := parser.NewParser(cfg.Rules, ignorer)
 
-	print, err := printer.NewPrinter(outputName, output.Stdout)
+	// Stop reading files on SIGINT, but still print the findings that were already found
+	ctx, stop := signalContext(cmd)
+	defer stop()
+
+	p.FailFast = failFast
+	p.Unsorted = unsorted
+	p.ReportUnusedIgnores = reportUnusedIgnores
+	findings, err := p.ParsePathsContext(ctx, print, parseArgs(args)...)
+	if closeErr := outs.close(); closeErr != nil {
+		// an output that couldn't be written is incomplete, even if every file was checked
+		cmd.SilenceUsage = true
+		return closeErr
+	}
 	if err != nil {
+		cmd.SilenceUsage = true
+		if errors.Is(err, context.Canceled) {
+			return fmt.Errorf("not all files were checked: %w", err)
+		}
+		// the results of some files couldn't be printed, such as fixes that couldn't be written
 		return err
 	}
 
-	findings := p.ParsePaths(print, parseArgs(args)...)
+	failures := findings
+	if fx != nil {
+		// findings that were fixed are no longer failures
+		failures = fx.Unfixed()
+	}
 
-	if exitOneOnFailure && findings > 0 {
+	if exitOneOnFailure && failures > 0 {
 		// We intentionally return an error if exitOneOnFailure is true, but don't want to show usage
 		cmd.SilenceUsage = true
-		err = fmt.Errorf("files with findings: %d", findings)
+		err = fmt.Errorf("files with findings: %d", failures)
 	}
 
 	if findings == 0 {
-		if print.PrintSuccessExitMessage() && cfg.GetSuccessExitMessage() != "" {
+		// if every output is written to a file, the success exit message is the only output on stdout
+		if (stdoutPrinter == nil || stdoutPrinter.PrintSuccessExitMessage()) && cfg.GetSuccessExitMessage() != "" {
 			fmt.Fprintln(output.Stdout, cfg.GetSuccessExitMessage())
 		}
 	}
@@ -138,6 +218,86 @@ func rootRunE(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// loadConfig loads the config file, and returns an error if no rules are enabled
+func loadConfig() (*config.Config, error) {
+	setRemoteOptions()
+	cfg, err := config.NewConfig(viper.ConfigFileUsed(), disableDefaultRules)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Rules) == 0 {
+		return nil, ErrNoRulesEnabled
+	}
+	return cfg, nil
+}
+
+// setRemoteOptions configures how remote configs are downloaded from flags
+func setRemoteOptions() {
+	config.Remote = config.RemoteOptions{
+		CacheDir: cacheDir,
+		Timeout:  configTimeout,
+		Offline:  offline,
+	}
+	if cacheDir == "" {
+		config.Remote.CacheDir = config.DefaultCacheDir()
+	}
+}
+
+// newParser returns a Parser for the rules in the config, with ignores and
+// git diffs configured from flags
+func newParser(cfg *config.Config) (*parser.Parser, error) {
+	var err error
+	// the git root is only needed to read ignore files and git diffs
+	var fs billy.Filesystem
+	if !noIgnore || diffBase != "" || staged {
+		fs, err = gitRootDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ignorer *ignore.Ignore
+	if !noIgnore {
+		ignorer, err = ignore.NewIgnore(fs, cfg.IgnoreFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p := parser.NewParser(cfg.Rules, ignorer)
+	p.Jobs = jobs
+	p.RequireIgnoreReason = cfg.RequireIgnoreReason
+	p.ExpireIgnores = cfg.ExpireIgnores
+
+	if diffBase != "" || staged {
+		p.Diff, err = gitdiff.NewDiff(fs.Root(), diffBase, staged)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// gitRootDir returns the root of the git repository of the working directory,
+// or the working directory if it isn't in a git repository
+func gitRootDir() (billy.Filesystem, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return ignore.GetRootGitDir(cwd)
+}
+
+// signalContext returns a context for the command that is cancelled on SIGINT
+func signalContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return signal.NotifyContext(ctx, os.Interrupt)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
@@ -149,12 +309,26 @@ func init() {
 	rootCmd.Version = getVersion("short")
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "Config file (default is .langcheck.yaml in current directory, or $HOME)")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Directory where remote config files are cached (default is language-checker in the user cache directory)")
+	rootCmd.PersistentFlags().DurationVar(&configTimeout, "config-timeout", config.Remote.Timeout, "Timeout for downloading remote config files")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Only use cached remote config files, without downloading them")
 	rootCmd.PersistentFlags().BoolVar(&exitOneOnFailure, "exit-1-on-failure", false, "Exit with exit code 1 on failures")
 	rootCmd.PersistentFlags().BoolVar(&stdin, "stdin", false, "Read from stdin")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&noIgnore, "no-ignore", false, "Ignored files in .gitignore, .ignore, .langcheckignore, .git/info/exclude, and inline ignores are processed")
-	rootCmd.PersistentFlags().StringVarP(&outputName, "output", "o", printer.OutFormatText, fmt.Sprintf("Output type [%s]", printer.OutFormatsString))
+	rootCmd.PersistentFlags().StringArrayVarP(&outputNames, "output", "o", []string{printer.OutFormatText}, fmt.Sprintf("Output type [%s], written to a file with type=path. Can be repeated for several outputs", printer.OutFormatsString))
 	rootCmd.PersistentFlags().BoolVar(&disableDefaultRules, "disable-default-rules", false, "Disable the default ruleset")
+	rootCmd.PersistentFlags().BoolVar(&fix, "fix", false, "Replace findings in files with the first alternative of the rule")
+	rootCmd.PersistentFlags().BoolVar(&fixDryRun, "fix-dry-run", false, "Show a unified diff of the changes --fix would make, without modifying files")
+	rootCmd.PersistentFlags().StringVar(&diffBase, "diff", "", "Only report findings on lines added or modified relative to this git ref")
+	rootCmd.PersistentFlags().BoolVar(&staged, "staged", false, "Only report findings on lines added or modified in staged changes")
+	rootCmd.PersistentFlags().StringVar(&baselineFile, "baseline", "", "Baseline file of existing findings that should not be reported")
+	rootCmd.PersistentFlags().IntVarP(&jobs, "jobs", "j", 0, "Number of files to read in parallel (default is the number of CPUs)")
+	rootCmd.PersistentFlags().BoolVar(&failFast, "fail-fast", false, "Stop checking files after the first file with findings")
+	rootCmd.PersistentFlags().BoolVar(&unsorted, "unsorted", false, "Print findings as soon as each file is checked, instead of sorted by filename")
+	rootCmd.PersistentFlags().BoolVar(&includePassing, "include-passing", false, "Include files without findings as passing test cases, for junit output")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template", "", "Template file to render findings with, for template output")
+	rootCmd.PersistentFlags().BoolVar(&reportUnusedIgnores, "report-unused-ignores", false, "Report langcheckignore:rule= directives that don't ignore any findings or name unknown rules")
 }
 
 // GetRootCmd returns the rootCmd, which should only be used by the docs generator in cmd/docs/main.go
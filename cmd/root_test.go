@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -137,15 +138,133 @@ func TestRunE(t *testing.T) {
 	})
 
 	t.Run("invalid printer", func(t *testing.T) {
-		outputName = "foo"
+		outputNames = []string{"foo"}
 		t.Cleanup(func() {
-			outputName = "text"
+			outputNames = []string{"text"}
 		})
 		err := rootRunE(new(cobra.Command), []string{"../testdata"})
 		assert.Error(t, err)
 		assert.Equal(t, "foo is not a valid printer type", err.Error())
 	})
 
+	t.Run("multiple outputs", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		output.Stdout = buf
+		dir := t.TempDir()
+		outputNames = []string{"simple", "json=" + filepath.Join(dir, "out.json"), "junit=" + filepath.Join(dir, "junit.xml")}
+		t.Cleanup(func() {
+			outputNames = []string{"text"}
+		})
+
+		f := filepath.Join(t.TempDir(), "findings.txt")
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+		err := rootRunE(new(cobra.Command), []string{f})
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), f+":1:11: [warning] `whitelist` may be insensitive")
+
+		b, err := os.ReadFile(filepath.Join(dir, "out.json"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `"Filename":"`+f+`"`)
+
+		b, err = os.ReadFile(filepath.Join(dir, "junit.xml"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `<testcase name="`+f+`" classname="language-checker">`)
+	})
+
+	t.Run("output that can't be written", func(t *testing.T) {
+		if _, err := os.Stat("/dev/full"); err != nil {
+			t.Skip("writing to /dev/full always fails, but it doesn't exist")
+		}
+		buf := new(bytes.Buffer)
+		output.Stdout = buf
+		dir := t.TempDir()
+		outputNames = []string{"simple", "json=/dev/full", "sarif=" + filepath.Join(dir, "out.sarif")}
+		t.Cleanup(func() {
+			outputNames = []string{"text"}
+		})
+
+		f := filepath.Join(t.TempDir(), "findings.txt")
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+		err := rootRunE(new(cobra.Command), []string{f})
+		assert.ErrorContains(t, err, "unable to write json output to /dev/full")
+		// the other outputs are still written
+		assert.Contains(t, buf.String(), f+":1:11: [warning] `whitelist` may be insensitive")
+		assert.FileExists(t, filepath.Join(dir, "out.sarif"))
+	})
+
+	t.Run("template that fails", func(t *testing.T) {
+		output.Stdout = new(bytes.Buffer)
+		templateFile = filepath.Join(t.TempDir(), "report.tmpl")
+		assert.NoError(t, os.WriteFile(templateFile, []byte("{{range .Files}}{{.Missing}}{{end}}"), 0o644))
+		outputNames = []string{"template"}
+		t.Cleanup(func() {
+			outputNames = []string{"text"}
+			templateFile = ""
+		})
+
+		f := filepath.Join(t.TempDir(), "findings.txt")
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+		err := rootRunE(new(cobra.Command), []string{f})
+		assert.ErrorContains(t, err, "unable to execute template")
+	})
+
+	t.Run("fix dry run", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		output.Stdout = buf
+		fixDryRun = true
+		t.Cleanup(func() {
+			fixDryRun = false
+		})
+
+		f := filepath.Join(t.TempDir(), "fix.txt")
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+		err := rootRunE(new(cobra.Command), []string{f})
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "+this has a allowlist\n")
+	})
+
+	t.Run("fix with exit 1 on failure", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		output.Stdout = buf
+		fix = true
+		exitOneOnFailure = true
+		t.Cleanup(func() {
+			fix = false
+			exitOneOnFailure = false
+		})
+
+		f := filepath.Join(t.TempDir(), "fix.txt")
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+
+		// every finding is fixed
+		assert.NoError(t, rootRunE(new(cobra.Command), []string{f}))
+		assert.Equal(t, f+": fixed 1 finding(s)\n", buf.String())
+
+		// nothing is fixed in a dry run, so the findings are still failures
+		assert.NoError(t, os.WriteFile(f, []byte("this has a whitelist\n"), 0o644)) // langcheckignore:rule=whitelist
+		fix, fixDryRun = false, true
+		t.Cleanup(func() {
+			fixDryRun = false
+		})
+		err := rootRunE(new(cobra.Command), []string{f})
+		assert.EqualError(t, err, "files with findings: 1")
+	})
+
+	t.Run("fix with stdin", func(t *testing.T) {
+		fix = true
+		stdin = true
+		t.Cleanup(func() {
+			fix = false
+			stdin = false
+		})
+		err := rootRunE(new(cobra.Command), []string{})
+		assert.ErrorIs(t, err, ErrFixWithStdin)
+	})
+
 	t.Run("invalid config", func(t *testing.T) {
 		setTestConfigFile(t, "../testdata/invalid.yaml")
 		err := rootRunE(new(cobra.Command), []string{"../testdata"})
@@ -174,3 +293,14 @@ func overrideHomeDir(t *testing.T) {
 	os.Setenv("HOME", "foo")
 	homedir.Reset()
 }
+
+func TestRootCmdArgs(t *testing.T) {
+	cmd, args, err := rootCmd.Find([]string{"README.md"})
+	assert.NoError(t, err)
+	assert.Equal(t, rootCmd, cmd)
+	assert.Equal(t, []string{"README.md"}, args)
+
+	cmd, _, err = rootCmd.Find([]string{"baseline", "create"})
+	assert.NoError(t, err)
+	assert.Equal(t, baselineCreateCmd, cmd)
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	rulesFormatTable = "table"
+	rulesFormatJSON  = "json"
+	rulesFormatYAML  = "yaml"
+)
+
+var rulesFormats = []string{rulesFormatTable, rulesFormatJSON, rulesFormatYAML}
+
+// ErrRuleNotFound is returned when a rule isn't one of the enabled rules
+var ErrRuleNotFound = errors.New("rule not found")
+
+var rulesFormat string
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect the enabled rules",
+	Long: `
+Inspect the rules that are enabled: the default rules, merged with the rules in the
+config file and any configs it extends, without the rules in excluded categories.
+Each rule shows its source, which is "default" or the config file that defines it,
+or both if the config file overrides some fields of a default rule.`,
+}
+
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the enabled rules",
+	Args:  cobra.NoArgs,
+	RunE:  rulesListRunE,
+}
+
+var rulesShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show an enabled rule",
+	Args:  cobra.ExactArgs(1),
+	RunE:  rulesShowRunE,
+}
+
+var rulesSearchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Search the enabled rules by name and term",
+	Long: `
+Search the enabled rules for rules whose name or terms contain the term, ignoring
+case, and rules that have findings in the term. This finds the rule that reported
+a finding from the text of the finding.`,
+	Args: cobra.ExactArgs(1),
+	RunE: rulesSearchRunE,
+}
+
+// ruleInfo is a rule as it is shown by the rules commands
+type ruleInfo struct {
+	Name         string   `json:"name" yaml:"name"`
+	Terms        []string `json:"terms" yaml:"terms"`
+	Alternatives []string `json:"alternatives,omitempty" yaml:"alternatives,omitempty"`
+	Exceptions   []string `json:"exceptions,omitempty" yaml:"exceptions,omitempty"`
+	Note         string   `json:"note,omitempty" yaml:"note,omitempty"`
+	Severity     string   `json:"severity" yaml:"severity"`
+	Scope        string   `json:"scope" yaml:"scope"`
+	Categories   []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+	IncludePaths []string `json:"include_paths,omitempty" yaml:"include_paths,omitempty"`
+	ExcludePaths []string `json:"exclude_paths,omitempty" yaml:"exclude_paths,omitempty"`
+	Source       string   `json:"source" yaml:"source"`
+}
+
+func newRuleInfo(r *rule.Rule) ruleInfo {
+	return ruleInfo{
+		Name:         r.Name,
+		Terms:        r.Terms,
+		Alternatives: r.Alternatives,
+		Exceptions:   r.Exceptions,
+		Note:         r.Note,
+		Severity:     r.Severity.String(),
+		Scope:        r.Scope().String(),
+		Categories:   r.Options.Categories,
+		IncludePaths: r.Options.IncludePaths,
+		ExcludePaths: r.Options.ExcludePaths,
+		Source:       r.Source,
+	}
+}
+
+func rulesListRunE(cmd *cobra.Command, args []string) error {
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	return printRules(output.Stdout, rules)
+}
+
+func rulesShowRunE(cmd *cobra.Command, args []string) error {
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if r.Name == args[0] {
+			return printRule(output.Stdout, r)
+		}
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%w: %s", ErrRuleNotFound, args[0])
+}
+
+func rulesSearchRunE(cmd *cobra.Command, args []string) error {
+	rules, err := loadRules()
+	if err != nil {
+		return err
+	}
+	return printRules(output.Stdout, searchRules(rules, args[0]))
+}
+
+// loadRules returns the enabled rules, after checking the format flag.
+// Disabled rules are left out, since they never have findings.
+func loadRules() ([]*rule.Rule, error) {
+	setDebugLogLevel()
+
+	if !isRulesFormat(rulesFormat) {
+		return nil, fmt.Errorf("invalid format %q, must be one of: %s", rulesFormat, strings.Join(rulesFormats, ", "))
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]*rule.Rule, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		if !r.Disabled() {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func isRulesFormat(format string) bool {
+	for _, f := range rulesFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// searchRules returns the rules whose name or terms contain the query, or that have findings in the query
+func searchRules(rules []*rule.Rule, query string) []*rule.Rule {
+	q := strings.ToLower(query)
+	found := []*rule.Rule{}
+	for _, r := range rules {
+		if strings.Contains(strings.ToLower(r.Name), q) || containsTerm(r.Terms, q) || len(r.FindMatchIndexes(query)) > 0 {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+func containsTerm(terms []string, q string) bool {
+	for _, t := range terms {
+		if strings.Contains(strings.ToLower(t), q) {
+			return true
+		}
+	}
+	return false
+}
+
+func printRules(w io.Writer, rules []*rule.Rule) error {
+	infos := make([]ruleInfo, len(rules))
+	for i, r := range rules {
+		infos[i] = newRuleInfo(r)
+	}
+
+	switch rulesFormat {
+	case rulesFormatJSON:
+		return printRulesJSON(w, infos)
+	case rulesFormatYAML:
+		return yaml.NewEncoder(w).Encode(infos)
+	}
+
+	if len(infos) == 0 {
+		_, err := fmt.Fprintln(w, "No rules found.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSEVERITY\tSCOPE\tTERMS\tSOURCE")
+	for _, r := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Name, r.Severity, r.Scope, strings.Join(r.Terms, ", "), r.Source)
+	}
+	return tw.Flush()
+}
+
+func printRule(w io.Writer, r *rule.Rule) error {
+	info := newRuleInfo(r)
+
+	switch rulesFormat {
+	case rulesFormatJSON:
+		return printRulesJSON(w, info)
+	case rulesFormatYAML:
+		return yaml.NewEncoder(w).Encode(info)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", info.Name)
+	fmt.Fprintf(tw, "Terms:\t%s\n", strings.Join(info.Terms, ", "))
+	fmt.Fprintf(tw, "Alternatives:\t%s\n", strings.Join(info.Alternatives, ", "))
+	if len(info.Exceptions) > 0 {
+		fmt.Fprintf(tw, "Exceptions:\t%s\n", strings.Join(info.Exceptions, ", "))
+	}
+	if info.Note != "" {
+		fmt.Fprintf(tw, "Note:\t%s\n", info.Note)
+	}
+	fmt.Fprintf(tw, "Severity:\t%s\n", info.Severity)
+	fmt.Fprintf(tw, "Scope:\t%s\n", info.Scope)
+	if len(info.Categories) > 0 {
+		fmt.Fprintf(tw, "Categories:\t%s\n", strings.Join(info.Categories, ", "))
+	}
+	if len(info.IncludePaths) > 0 {
+		fmt.Fprintf(tw, "Include paths:\t%s\n", strings.Join(info.IncludePaths, ", "))
+	}
+	if len(info.ExcludePaths) > 0 {
+		fmt.Fprintf(tw, "Exclude paths:\t%s\n", strings.Join(info.ExcludePaths, ", "))
+	}
+	fmt.Fprintf(tw, "Source:\t%s\n", info.Source)
+	return tw.Flush()
+}
+
+func printRulesJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func init() {
+	rulesCmd.PersistentFlags().StringVar(&rulesFormat, "format", rulesFormatTable, fmt.Sprintf("Output format [%s]", strings.Join(rulesFormats, ",")))
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesShowCmd)
+	rulesCmd.AddCommand(rulesSearchCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
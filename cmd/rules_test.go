@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/output"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	origStdout := output.Stdout
+	t.Cleanup(func() {
+		output.Stdout = origStdout
+		rulesFormat = rulesFormatTable
+	})
+	overrideHomeDir(t)
+	setTestConfigFile(t, "../pkg/config/testdata/good.yaml")
+
+	buf := new(bytes.Buffer)
+	output.Stdout = buf
+
+	t.Run("list", func(t *testing.T) {
+		buf.Reset()
+		rulesFormat = rulesFormatTable
+		assert.NoError(t, rulesListRunE(new(cobra.Command), nil))
+		assert.Regexp(t, `^NAME +SEVERITY +SCOPE +TERMS +SOURCE\n`, buf.String())
+		assert.Regexp(t, `\nrule1 +warning +all +rule1 +../pkg/config/testdata/good.yaml\n`, buf.String())
+		assert.Regexp(t, `\nslave +error +all +slave +default\n`, buf.String())
+	})
+
+	t.Run("show", func(t *testing.T) {
+		buf.Reset()
+		rulesFormat = rulesFormatJSON
+		assert.NoError(t, rulesShowRunE(new(cobra.Command), []string{"rule2"}))
+		var info ruleInfo
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+		assert.Equal(t, ruleInfo{
+			Name:         "rule2",
+			Terms:        []string{"rule2", "rule-2"},
+			Alternatives: []string{"alt-rule2", "alt-rule-2"},
+			Severity:     "error",
+			Scope:        "all",
+			Source:       "../pkg/config/testdata/good.yaml",
+		}, info)
+
+		assert.ErrorIs(t, rulesShowRunE(new(cobra.Command), []string{"missing"}), ErrRuleNotFound)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		buf.Reset()
+		rulesFormat = rulesFormatYAML
+		assert.NoError(t, rulesSearchRunE(new(cobra.Command), []string{"the Slave node"}))
+		assert.Contains(t, buf.String(), "- name: slave\n")
+		assert.NotContains(t, buf.String(), "- name: rule1\n")
+
+		buf.Reset()
+		rulesFormat = rulesFormatTable
+		assert.NoError(t, rulesSearchRunE(new(cobra.Command), []string{"nothing matches this"}))
+		assert.Equal(t, "No rules found.\n", buf.String())
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		rulesFormat = "xml"
+		assert.EqualError(t, rulesListRunE(new(cobra.Command), nil), `invalid format "xml", must be one of: table, json, yaml`)
+	})
+}
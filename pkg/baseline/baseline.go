@@ -0,0 +1,197 @@
+package baseline
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jdstrand/language-checker/pkg/printer"
+	"github.com/jdstrand/language-checker/pkg/result"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultFilename is the default filename for a baseline
+const DefaultFilename = ".langcheck-baseline.yaml"
+
+// version is the version of the baseline file format
+const version = 1
+
+// Baseline contains findings that already exist, which should not be reported
+type Baseline struct {
+	Version  int       `yaml:"version"`
+	Findings []Finding `yaml:"findings"`
+
+	root      string
+	remaining map[key]int
+}
+
+// Finding is a finding in a baseline. Findings are identified by a fingerprint
+// of the line text rather than the line number, so they survive unrelated changes to the file.
+type Finding struct {
+	Rule        string `yaml:"rule"`
+	File        string `yaml:"file"`
+	Fingerprint string `yaml:"fingerprint"`
+	// Count is the number of identical findings, for example if the same line is repeated in a file
+	Count int `yaml:"count,omitempty"`
+}
+
+type key struct {
+	rule, file, fingerprint string
+}
+
+func newKey(root, filename string, r result.Result) key {
+	return key{
+		rule:        r.GetRuleName(),
+		file:        RelativePath(root, filename),
+		fingerprint: result.Fingerprint(r),
+	}
+}
+
+func normalize(filename string) string {
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+// RelativePath returns the filename relative to root, usually the root of the git repository,
+// so the same file has the same path no matter which directory language-checker runs from.
+// Files outside of root are returned as an absolute path. If root is empty, the filename is only cleaned.
+func RelativePath(root, filename string) string {
+	if root == "" {
+		return normalize(filename)
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return normalize(filename)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return normalize(abs)
+	}
+	return normalize(rel)
+}
+
+// Load reads a baseline from filename. The files of its findings are relative to root,
+// which should be the same root the baseline was written with.
+func Load(filename, root string) (*Baseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Baseline
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", filename, err)
+	}
+	if b.Version != version {
+		return nil, fmt.Errorf("invalid baseline %s: unsupported version %d", filename, b.Version)
+	}
+
+	b.root = root
+	b.remaining = make(map[key]int, len(b.Findings))
+	for _, f := range b.Findings {
+		count := f.Count
+		if count == 0 {
+			count = 1
+		}
+		b.remaining[key{rule: f.Rule, file: normalize(f.File), fingerprint: f.Fingerprint}] += count
+	}
+
+	log.Debug().Str("baseline", filename).Int("findings", len(b.Findings)).Msg("loaded baseline")
+	return &b, nil
+}
+
+// Filter removes the results from FileResults that are in the baseline.
+// Each finding in the baseline can only filter out as many results as its count,
+// so new occurrences of an existing finding are still reported.
+func (b *Baseline) Filter(fs *result.FileResults) {
+	results := fs.Results[:0]
+	for _, r := range fs.Results {
+		k := newKey(b.root, fs.Filename, r)
+		if b.remaining[k] > 0 {
+			b.remaining[k]--
+			log.Debug().
+				Str("rule", r.GetRuleName()).
+				Str("file", fs.Filename).
+				Int("line", r.GetStartPosition().Line).
+				Msg("ignoring via baseline")
+			continue
+		}
+		results = append(results, r)
+	}
+	fs.Results = results
+}
+
+// Writer is a printer that writes all results it receives as a baseline
+type Writer struct {
+	writer   io.Writer
+	root     string
+	findings map[key]int
+}
+
+// compile-time check that Writer satisfies the Printer interface
+var _ printer.Printer = (*Writer)(nil)
+
+// NewWriter returns a new Writer that writes a baseline to w, with the files of its findings relative to root
+func NewWriter(w io.Writer, root string) *Writer {
+	return &Writer{writer: w, root: root, findings: map[key]int{}}
+}
+
+func (w *Writer) PrintSuccessExitMessage() bool {
+	return false
+}
+
+func (w *Writer) Start() {
+}
+
+// Print adds all results in FileResults to the baseline.
+// NOTE: Nothing is written until End() is called.
+func (w *Writer) Print(fs *result.FileResults) error {
+	for _, r := range fs.Results {
+		w.findings[newKey(w.root, fs.Filename, r)]++
+	}
+	return nil
+}
+
+// End writes the baseline, sorted so that the output is stable
+func (w *Writer) End() {
+	b := Baseline{Version: version, Findings: []Finding{}}
+	for k, count := range w.findings {
+		f := Finding{Rule: k.rule, File: k.file, Fingerprint: k.fingerprint}
+		if count > 1 {
+			f.Count = count
+		}
+		b.Findings = append(b.Findings, f)
+	}
+
+	sort.Slice(b.Findings, func(i, j int) bool {
+		fi, fj := b.Findings[i], b.Findings[j]
+		if fi.File != fj.File {
+			return fi.File < fj.File
+		}
+		if fi.Rule != fj.Rule {
+			return fi.Rule < fj.Rule
+		}
+		return fi.Fingerprint < fj.Fingerprint
+	})
+
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := w.writer.Write(data); err != nil {
+		panic(err)
+	}
+}
+
+// Len returns the number of findings written to the baseline
+func (w *Writer) Len() int {
+	n := 0
+	for _, count := range w.findings {
+		n += count
+	}
+	return n
+}
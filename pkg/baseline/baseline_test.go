@@ -0,0 +1,123 @@
+package baseline
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fileResults(filename string, lines ...string) *result.FileResults {
+	fs := &result.FileResults{Filename: filename}
+	for i, l := range lines {
+		fs.Results = append(fs.Results, result.FindResults(&rule.TestRule, filename, l, i+1)...)
+	}
+	return fs
+}
+
+func TestWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf, "")
+	assert.False(t, w.PrintSuccessExitMessage())
+
+	w.Start()
+	assert.NoError(t, w.Print(fileResults("./b.txt", "has whitelist", "has whitelist")))
+	assert.NoError(t, w.Print(fileResults("a.txt", "has whitelist")))
+	w.End()
+
+	assert.Equal(t, 3, w.Len())
+	expected := `version: 1
+findings:
+- rule: whitelist
+  file: a.txt
+  fingerprint: ` + result.Fingerprint(fileResults("a.txt", "has whitelist").Results[0]) + `
+- rule: whitelist
+  file: b.txt
+  fingerprint: ` + result.Fingerprint(fileResults("a.txt", "has whitelist").Results[0]) + `
+  count: 2
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLoadAndFilter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf, "")
+	assert.NoError(t, w.Print(fileResults("a.txt", "has whitelist", "also has whitelist", "also has whitelist")))
+	w.End()
+
+	filename := filepath.Join(t.TempDir(), DefaultFilename)
+	assert.NoError(t, os.WriteFile(filename, buf.Bytes(), 0o644))
+
+	b, err := Load(filename, "")
+	assert.NoError(t, err)
+
+	// lines were moved, and a new occurrence of an existing line was added
+	fs := fileResults("./a.txt", "new line", "also has whitelist", "has whitelist", "also has whitelist", "also has whitelist", "new whitelist")
+	b.Filter(fs)
+	assert.Len(t, fs.Results, 2)
+	assert.Equal(t, 5, fs.Results[0].GetStartPosition().Line)
+	assert.Equal(t, 6, fs.Results[1].GetStartPosition().Line)
+
+	// the same findings in a different file are still reported
+	fs = fileResults("b.txt", "has whitelist")
+	b.Filter(fs)
+	assert.Len(t, fs.Results, 1)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.yaml"), "")
+	assert.Error(t, err)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalid, []byte("findings: [\n"), 0o644))
+	_, err = Load(invalid, "")
+	assert.Error(t, err)
+
+	unsupported := filepath.Join(dir, "unsupported.yaml")
+	assert.NoError(t, os.WriteFile(unsupported, []byte("version: 2\nfindings: []\n"), 0o644))
+	_, err = Load(unsupported, "")
+	assert.EqualError(t, err, "invalid baseline "+unsupported+": unsupported version 2")
+}
+
+func TestLoadAndFilter_Root(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	assert.NoError(t, os.Mkdir(sub, 0o755))
+
+	// the baseline is created with an absolute path
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf, root)
+	assert.NoError(t, w.Print(fileResults(filepath.Join(sub, "a.txt"), "has whitelist")))
+	w.End()
+	assert.Contains(t, buf.String(), "file: sub/a.txt\n")
+
+	filename := filepath.Join(root, DefaultFilename)
+	assert.NoError(t, os.WriteFile(filename, buf.Bytes(), 0o644))
+
+	// and used from a subdirectory, with a relative path
+	t.Chdir(sub)
+	b, err := Load(filename, root)
+	assert.NoError(t, err)
+	fs := fileResults("a.txt", "has whitelist")
+	b.Filter(fs)
+	assert.Empty(t, fs.Results)
+}
+
+func TestRelativePath(t *testing.T) {
+	root := t.TempDir()
+	assert.Equal(t, "a/b.txt", RelativePath("", "./a/b.txt"))
+	assert.Equal(t, "a/b.txt", RelativePath(root, filepath.Join(root, "a", "b.txt")))
+	assert.Equal(t, "b.txt", RelativePath(root, filepath.Join(root, "a", "..", "b.txt")))
+
+	// files outside of the root are absolute
+	outside := filepath.Join(filepath.Dir(root), "b.txt")
+	assert.Equal(t, filepath.ToSlash(outside), RelativePath(root, outside))
+	assert.Equal(t, filepath.ToSlash(outside), RelativePath(root, filepath.Join(root, "..", "b.txt")))
+}
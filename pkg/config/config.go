@@ -1,11 +1,8 @@
 package config
 
 import (
-	"context"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
 
@@ -18,11 +15,19 @@ import (
 
 // Config contains a list of rules
 type Config struct {
+	// Extends are the paths or URLs of configs that this config overrides
+	Extends            []string     `yaml:"extends"`
 	Rules              []*rule.Rule `yaml:"rules"`
 	IgnoreFiles        []string     `yaml:"ignore_files"`
 	SuccessExitMessage *string      `yaml:"success_exit_message"`
 	IncludeNote        bool         `yaml:"include_note"`
 	ExcludeCategories  []string     `yaml:"exclude_categories"`
+	// Scope, if set, is the scope of all rules that don't set their own scope
+	Scope *rule.Scope `yaml:"scope"`
+	// RequireIgnoreReason only allows ignore directives with a reason to ignore findings
+	RequireIgnoreReason bool `yaml:"require_ignore_reason"`
+	// ExpireIgnores stops ignore directives from ignoring findings after their until date
+	ExpireIgnores bool `yaml:"expire_ignores"`
 }
 
 // NewConfig returns a new Config
@@ -31,12 +36,12 @@ func NewConfig(filename string, disableDefaultRules bool) (*Config, error) {
 	if len(filename) > 0 {
 		var err error
 
-		if isValidURL(filename) {
-			c, err = loadRemoteConfig(filename)
-		} else {
-			c, err = loadConfig(filename)
+		c, err = loadConfigFrom(filename)
+		if err != nil {
+			return nil, err
 		}
 
+		c, err = c.extend(filename, []string{extendsKey(filename)})
 		if err != nil {
 			return nil, err
 		}
@@ -65,16 +70,8 @@ func (c *Config) GetSuccessExitMessage() string {
 	return *c.SuccessExitMessage
 }
 
-func (c *Config) inExistingRules(r *rule.Rule) bool {
-	for _, n := range c.Rules {
-		if n.Name == r.Name {
-			return true
-		}
-	}
-	return false
-}
-
 // ConfigureRules adds the config Rules to DefaultRules
+// Config Rules without terms only override the fields they set of the DefaultRules with the same name
 // Configure RegExps for all rules
 // Configure IncludeNote for all rules
 // Filter out any rules that fall under ExcludeCategories
@@ -82,11 +79,7 @@ func (c *Config) ConfigureRules(disableDefaultRules bool) {
 	if disableDefaultRules {
 		log.Debug().Msg("disabling default rules")
 	} else {
-		for _, r := range rule.DefaultRules {
-			if !c.inExistingRules(r) {
-				c.Rules = append(c.Rules, r)
-			}
-		}
+		c.Rules = overrideDefaultRules(c.Rules)
 	}
 	logRuleset("default", rule.DefaultRules)
 	var excludeIndices []int
@@ -103,6 +96,9 @@ RuleLoop:
 
 		r.SetRegexp()
 		r.SetIncludeNote(c.IncludeNote)
+		if c.Scope != nil {
+			r.SetScope(*c.Scope)
+		}
 	}
 
 	// Remove excluded rules after done iterating through them
@@ -119,6 +115,31 @@ RuleLoop:
 	}
 }
 
+// overrideDefaultRules returns the rules, overriding the default rules with the same name,
+// followed by the default rules that aren't overridden
+func overrideDefaultRules(rules []*rule.Rule) []*rule.Rule {
+	defaults := make(map[string]*rule.Rule, len(rule.DefaultRules))
+	for _, r := range rule.DefaultRules {
+		defaults[r.Name] = r
+	}
+
+	merged := make([]*rule.Rule, 0, len(rules)+len(rule.DefaultRules))
+	for _, r := range rules {
+		if d, ok := defaults[r.Name]; ok {
+			log.Debug().Str("rule", r.Name).Msg("overriding default rule")
+			r = d.Override(r)
+			delete(defaults, r.Name)
+		}
+		merged = append(merged, r)
+	}
+	for _, r := range rule.DefaultRules {
+		if _, ok := defaults[r.Name]; ok {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
 // Remove rule at index i in c.Rules while maintaining order
 func (c *Config) RemoveRule(i int) {
 	if i >= len(c.Rules) || i < 0 {
@@ -127,42 +148,33 @@ func (c *Config) RemoveRule(i int) {
 	c.Rules = append(c.Rules[:i], c.Rules[i+1:]...)
 }
 
-func loadConfig(filename string) (c Config, err error) {
-	yamlFile, err := ioutil.ReadFile(filename)
-	log.Debug().Str("filename", filename).Msg("Adding custom ruleset from")
-	if err != nil {
-		return c, err
+// loadConfigFrom loads the config from the path or URL, and sets it as the Source of its rules
+func loadConfigFrom(location string) (c Config, err error) {
+	source := location
+	if isValidURL(location) {
+		c, err = loadRemoteConfig(location)
+	} else {
+		c, err = loadConfig(location)
+		source = relative(location)
 	}
-	return c, yaml.Unmarshal(yamlFile, &c)
-}
-
-// gets the remote config from the url provided and returns config
-func loadRemoteConfig(url string) (c Config, err error) {
-	log.Debug().Str("url", url).Msg("Downloading file from")
-	client := &http.Client{}
-	ctx := context.Background()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return c, err
 	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return c, err
+	for _, r := range c.Rules {
+		if r != nil {
+			r.Source = source
+		}
 	}
-	body, err := io.ReadAll(resp.Body)
+	return c, nil
+}
+
+func loadConfig(filename string) (c Config, err error) {
+	yamlFile, err := ioutil.ReadFile(filename)
+	log.Debug().Str("filename", filename).Msg("Adding custom ruleset from")
 	if err != nil {
 		return c, err
 	}
-	defer resp.Body.Close()
-
-	// only parse response body if it is in the response is in the 2xx range
-	statusOK := resp.StatusCode >= 200 && resp.StatusCode <= 299
-	if !statusOK {
-		return c, fmt.Errorf("unable to download remote config from url. Response code: %v. Response body: %c", resp.StatusCode, body)
-	}
-
-	log.Debug().Int("HTTP Response Status:", resp.StatusCode).Msg("Valid URL Response")
-	return c, yaml.Unmarshal(body, &c)
+	return c, yaml.Unmarshal(yamlFile, &c)
 }
 
 func relative(filename string) string {
@@ -46,10 +46,11 @@ func TestNewConfig(t *testing.T) {
 		loadedRemoteConfig := `{"level":"debug","filename":"testdata/good.yaml","message":"Adding custom ruleset from"}`
 		loadedConfigMsg := `{"level":"debug","config":"testdata/good.yaml","message":"loaded config file"}`
 		configRulesMsg := fmt.Sprintf(`{"level":"debug","rules":[%s],"message":"config rules"}`, strings.Join(configRules, ","))
+		overrideRuleMsg := `{"level":"debug","rule":"whitelist","message":"overriding default rule"}`
 		defaultRulesMsg := fmt.Sprintf(`{"level":"debug","rules":[%s],"message":"default rules"}`, strings.Join(defaultRules, ","))
 		allRulesMsg := fmt.Sprintf(`{"level":"debug","rules":[%s],"message":"all enabled rules"}`, strings.Join(enabledRules, ","))
 		assert.Equal(t,
-			loadedRemoteConfigMsg+"\n"+loadedRemoteConfig+"\n"+loadedConfigMsg+"\n"+configRulesMsg+"\n"+defaultRulesMsg+"\n"+allRulesMsg+"\n",
+			loadedRemoteConfigMsg+"\n"+loadedRemoteConfig+"\n"+loadedConfigMsg+"\n"+configRulesMsg+"\n"+overrideRuleMsg+"\n"+defaultRulesMsg+"\n"+allRulesMsg+"\n",
 			out.String())
 	})
 
@@ -63,18 +64,21 @@ func TestNewConfig(t *testing.T) {
 			Terms:        []string{"rule1"},
 			Alternatives: []string{"alt-rule1"},
 			Severity:     rule.SevWarn,
+			Source:       "testdata/good.yaml",
 		})
 		expectedRules = append(expectedRules, &rule.Rule{
 			Name:         "rule2",
 			Terms:        []string{"rule2", "rule-2"},
 			Alternatives: []string{"alt-rule2", "alt-rule-2"},
 			Severity:     rule.SevError,
+			Source:       "testdata/good.yaml",
 		})
 		expectedRules = append(expectedRules, &rule.Rule{
 			Name:         "whitelist",
 			Terms:        []string{"rulewl", "rule-wl"},
 			Alternatives: []string{"alt-rulewl", "alt-rule-wl"},
 			Severity:     rule.SevError,
+			Source:       "testdata/good.yaml",
 		})
 
 		expected := &Config{
@@ -156,6 +160,73 @@ func TestNewConfig(t *testing.T) {
 		assert.Equal(t, true, *c.Rules[0].Options.IncludeNote)
 	})
 
+	t.Run("config-scope", func(t *testing.T) {
+		c, err := NewConfig("testdata/scope.yaml", true)
+		assert.NoError(t, err)
+
+		// check global Scope
+		assert.Equal(t, rule.ScopeComments, *c.Scope)
+
+		// check Scope is not overridden for rule1
+		assert.Equal(t, rule.ScopeStrings, c.Rules[0].Scope())
+
+		// check Scope is set for rule2
+		assert.Equal(t, rule.ScopeComments, c.Rules[1].Scope())
+	})
+
+	t.Run("config-invalid-scope", func(t *testing.T) {
+		c, err := NewConfig("testdata/invalid-scope.yaml", true)
+		assert.EqualError(t, err, `invalid scope "docstrings", must be one of: all, comments, strings, comments_and_strings`)
+		assert.Nil(t, c)
+	})
+
+	t.Run("config-ignore-policy", func(t *testing.T) {
+		c, err := NewConfig("testdata/ignore-policy.yaml", true)
+		assert.NoError(t, err)
+		assert.True(t, c.RequireIgnoreReason)
+		assert.True(t, c.ExpireIgnores)
+
+		c, err = NewConfig("testdata/scope.yaml", true)
+		assert.NoError(t, err)
+		assert.False(t, c.RequireIgnoreReason)
+		assert.False(t, c.ExpireIgnores)
+	})
+
+	t.Run("config-override-defaults", func(t *testing.T) {
+		c, err := NewConfig("testdata/override-defaults.yaml", false)
+		assert.NoError(t, err)
+		assert.Len(t, c.Rules, len(rule.DefaultRules))
+
+		rules := map[string]*rule.Rule{}
+		for _, r := range c.Rules {
+			rules[r.Name] = r
+		}
+		defaults := map[string]*rule.Rule{}
+		for _, r := range rule.DefaultRules {
+			defaults[r.Name] = r
+		}
+
+		assert.Equal(t, rule.SevInfo, rules["whitelist"].Severity)
+		assert.Equal(t, defaults["whitelist"].Terms, rules["whitelist"].Terms)
+		assert.Equal(t, defaults["whitelist"].Note, rules["whitelist"].Note)
+		assert.Equal(t, "default, overridden by testdata/override-defaults.yaml", rules["whitelist"].Source)
+		assert.False(t, rules["whitelist"].Disabled())
+
+		assert.True(t, rules["slave"].Disabled())
+		assert.Equal(t, defaults["slave"].Terms, rules["slave"].Terms)
+
+		// a rule with only a name still disables the default rule
+		assert.True(t, rules["grandfathered"].Disabled())
+		assert.Empty(t, rules["grandfathered"].Terms)
+
+		assert.True(t, rules["master-slave"].Options.WordBoundary)
+		assert.Equal(t, defaults["master-slave"].Severity, rules["master-slave"].Severity)
+
+		// default rules aren't modified
+		assert.Equal(t, rule.SevWarn, defaults["whitelist"].Severity)
+		assert.False(t, defaults["slave"].Disabled())
+	})
+
 	t.Run("disable-default-rules", func(t *testing.T) {
 		c, err := NewConfig("testdata/good.yaml", true)
 		assert.NoError(t, err)
@@ -177,6 +248,7 @@ func TestNewConfig(t *testing.T) {
 			Terms:        []string{"rule1"},
 			Alternatives: []string{"alt-rule1"},
 			Severity:     rule.SevWarn,
+			Source:       "testdata/exclude-single-category.yaml",
 			Options:      rule.Options{Categories: []string{"cat1"}},
 		})
 		expectedRules = append(expectedRules, &rule.Rule{
@@ -184,6 +256,7 @@ func TestNewConfig(t *testing.T) {
 			Terms:        []string{"rule3", "rule-3"},
 			Alternatives: []string{"alt-rule3", "alt-rule-3"},
 			Severity:     rule.SevError,
+			Source:       "testdata/exclude-single-category.yaml",
 		})
 
 		expected := &Config{
@@ -207,6 +280,7 @@ func TestNewConfig(t *testing.T) {
 			Terms:        []string{"rule3", "rule-3"},
 			Alternatives: []string{"alt-rule3", "alt-rule-3"},
 			Severity:     rule.SevError,
+			Source:       "testdata/exclude-multiple-categories.yaml",
 		})
 
 		expected := &Config{
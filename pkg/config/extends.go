@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+	"github.com/jdstrand/language-checker/pkg/util"
+
+	"github.com/rs/zerolog/log"
+)
+
+// extend merges the configs in c.Extends into c, in order, so that later configs override earlier ones,
+// and c overrides all of them. location is where c was loaded from, which relative paths in Extends are
+// resolved against. chain contains the locations of the configs that extend c, to detect cycles.
+func (c Config) extend(location string, chain []string) (Config, error) {
+	if len(c.Extends) == 0 {
+		return c, nil
+	}
+
+	var base Config
+	for _, ext := range c.Extends {
+		ext = resolveExtends(location, ext)
+		if util.InSlice(extendsKey(ext), chain) {
+			return c, fmt.Errorf("config %s extends itself through %s", location, ext)
+		}
+
+		log.Debug().Str("config", location).Str("extends", ext).Msg("extending config")
+		extended, err := loadConfigFrom(ext)
+		if err != nil {
+			return c, fmt.Errorf("unable to load config %s extended by %s: %w", ext, location, err)
+		}
+		// copy the chain, so configs extended by the same config don't share it
+		extended, err = extended.extend(ext, append(chain[:len(chain):len(chain)], extendsKey(ext)))
+		if err != nil {
+			return c, err
+		}
+		if !isValidURL(ext) {
+			// Ignore local configs, they will always match on their own rules
+			extended.IgnoreFiles = append(extended.IgnoreFiles, relative(ext))
+		}
+
+		base = base.merge(extended)
+	}
+
+	c.Extends = nil
+	return base.merge(c), nil
+}
+
+// merge returns c overridden by o:
+// - rules in o replace the rules in c with the same name, and the rest are added after the rules in c
+// - ignore_files and exclude_categories are combined
+// - success_exit_message and scope are replaced if they are set in o
+// - include_note, require_ignore_reason and expire_ignores are enabled if they are enabled in either
+func (c Config) merge(o Config) Config {
+	merged := c
+	merged.Rules = mergeRules(c.Rules, o.Rules)
+	merged.IgnoreFiles = appendUnique(c.IgnoreFiles, o.IgnoreFiles)
+	merged.ExcludeCategories = appendUnique(c.ExcludeCategories, o.ExcludeCategories)
+	if o.SuccessExitMessage != nil {
+		merged.SuccessExitMessage = o.SuccessExitMessage
+	}
+	if o.Scope != nil {
+		merged.Scope = o.Scope
+	}
+	merged.IncludeNote = c.IncludeNote || o.IncludeNote
+	merged.RequireIgnoreReason = c.RequireIgnoreReason || o.RequireIgnoreReason
+	merged.ExpireIgnores = c.ExpireIgnores || o.ExpireIgnores
+	return merged
+}
+
+func mergeRules(rules, overrides []*rule.Rule) []*rule.Rule {
+	merged := make([]*rule.Rule, len(rules), len(rules)+len(overrides))
+	copy(merged, rules)
+
+	index := make(map[string]int, len(rules))
+	for i, r := range merged {
+		index[r.Name] = i
+	}
+	for _, r := range overrides {
+		if i, ok := index[r.Name]; ok {
+			log.Debug().Str("rule", r.Name).Msg("overriding extended rule")
+			merged[i] = merged[i].Override(r)
+			continue
+		}
+		index[r.Name] = len(merged)
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// appendUnique returns a new slice with the elements of a, followed by the elements of b that aren't in a
+func appendUnique(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	merged := make([]string, 0, len(a)+len(b))
+	for _, s := range append(a[:len(a):len(a)], b...) {
+		if !util.InSlice(s, merged) {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// resolveExtends returns the location of ext, which is relative to the config at location,
+// unless ext is a URL or an absolute path
+func resolveExtends(location, ext string) string {
+	if isValidURL(ext) {
+		return ext
+	}
+	if isValidURL(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return ext
+		}
+		ref, err := url.Parse(ext)
+		if err != nil {
+			return ext
+		}
+		return base.ResolveReference(ref).String()
+	}
+	if filepath.IsAbs(ext) {
+		return ext
+	}
+	return filepath.Join(filepath.Dir(location), ext)
+}
+
+// extendsKey returns a key for the config location, so the same file is found
+// through different relative paths
+func extendsKey(location string) string {
+	if isValidURL(location) {
+		return location
+	}
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return location
+}
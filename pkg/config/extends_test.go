@@ -0,0 +1,136 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ruleNames(rules []*rule.Rule) []string {
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.Name
+	}
+	return names
+}
+
+func TestNewConfigExtends(t *testing.T) {
+	t.Run("extends", func(t *testing.T) {
+		c, err := NewConfig("testdata/extends/child.yaml", true)
+		assert.NoError(t, err)
+		assert.Nil(t, c.Extends)
+
+		// rule2 is overridden in place, and new rules are added after the extended rules
+		assert.Equal(t, []string{"rule1", "rule2", "rule3", "rule4"}, ruleNames(c.Rules))
+		assert.Equal(t, []string{"rule2", "rule-two"}, c.Rules[1].Terms)
+		assert.Equal(t, rule.SevWarn, c.Rules[1].Severity)
+
+		// each rule's source is the config that defines it
+		assert.Equal(t, filepath.Join("testdata", "extends", "base.yaml"), c.Rules[0].Source)
+		assert.Equal(t, "testdata/extends/child.yaml", c.Rules[1].Source)
+		assert.Equal(t, filepath.Join("testdata", "extends", "team.yaml"), c.Rules[2].Source)
+
+		assert.Equal(t, []string{
+			"vendor",
+			filepath.Join("testdata", "extends", "base.yaml"),
+			"third_party",
+			filepath.Join("testdata", "extends", "team.yaml"),
+			"testdata/extends/child.yaml",
+		}, c.IgnoreFiles)
+		assert.Equal(t, []string{"general", "cultural"}, c.ExcludeCategories)
+		assert.Equal(t, "base message", c.GetSuccessExitMessage())
+		assert.True(t, c.IncludeNote)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		c, err := NewConfig("testdata/extends/cycle-a.yaml", true)
+		assert.EqualError(t, err, "config testdata/extends/cycle-b.yaml extends itself through testdata/extends/cycle-a.yaml")
+		assert.Nil(t, c)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		c, err := NewConfig("testdata/extends/missing.yaml", true)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Regexp(t, "^unable to load config testdata/extends/does-not-exist.yaml extended by testdata/extends/missing.yaml: ", err.Error())
+		assert.Nil(t, c)
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/policy/base.yaml", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("rules:\n  - name: rule1\n    terms:\n      - rule1\nignore_files:\n  - vendor\n"))
+		})
+		mux.HandleFunc("/policy/team.yaml", func(w http.ResponseWriter, r *http.Request) {
+			// relative to the url of this config
+			w.Write([]byte("extends:\n  - base.yaml\nrules:\n  - name: rule2\n    terms:\n      - rule2\n"))
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		filename := filepath.Join(t.TempDir(), "langcheck.yaml")
+		assert.NoError(t, os.WriteFile(filename, []byte("extends:\n  - "+server.URL+"/policy/team.yaml\nrules:\n  - name: rule3\n    terms:\n      - rule3\n"), 0o600))
+
+		c, err := NewConfig(filename, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"rule1", "rule2", "rule3"}, ruleNames(c.Rules))
+		// remote configs aren't ignored, since they aren't files in the repository
+		assert.Equal(t, []string{"vendor", relative(filename)}, c.IgnoreFiles)
+	})
+}
+
+func TestConfig_merge(t *testing.T) {
+	message := "message"
+	scope := rule.ScopeComments
+	base := Config{
+		Rules:              []*rule.Rule{{Name: "a"}, {Name: "b"}},
+		IgnoreFiles:        []string{"vendor"},
+		SuccessExitMessage: &message,
+		Scope:              &scope,
+		ExpireIgnores:      true,
+	}
+	override := Config{
+		Rules:               []*rule.Rule{{Name: "c"}, {Name: "a", Terms: []string{"a"}}},
+		IgnoreFiles:         []string{"dist", "vendor"},
+		ExcludeCategories:   []string{"general"},
+		RequireIgnoreReason: true,
+	}
+
+	merged := base.merge(override)
+	assert.Equal(t, []string{"a", "b", "c"}, ruleNames(merged.Rules))
+	assert.Equal(t, []string{"a"}, merged.Rules[0].Terms)
+	assert.Equal(t, []string{"vendor", "dist"}, merged.IgnoreFiles)
+	assert.Equal(t, []string{"general"}, merged.ExcludeCategories)
+	assert.Equal(t, &message, merged.SuccessExitMessage)
+	assert.Equal(t, &scope, merged.Scope)
+	assert.True(t, merged.RequireIgnoreReason)
+	assert.True(t, merged.ExpireIgnores)
+
+	// the base config is not modified
+	assert.Equal(t, []string{"a", "b"}, ruleNames(base.Rules))
+	assert.Nil(t, base.Rules[0].Terms)
+	assert.Equal(t, []string{"vendor"}, base.IgnoreFiles)
+}
+
+func Test_resolveExtends(t *testing.T) {
+	tests := []struct {
+		location string
+		ext      string
+		expected string
+	}{
+		{"config/langcheck.yaml", "base.yaml", filepath.Join("config", "base.yaml")},
+		{"config/langcheck.yaml", "../base.yaml", "base.yaml"},
+		{"config/langcheck.yaml", "/etc/langcheck.yaml", "/etc/langcheck.yaml"},
+		{"config/langcheck.yaml", "https://example.com/base.yaml", "https://example.com/base.yaml"},
+		{"https://example.com/policy/team.yaml", "base.yaml", "https://example.com/policy/base.yaml"},
+		{"https://example.com/policy/team.yaml", "/base.yaml", "https://example.com/base.yaml"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, resolveExtends(tt.location, tt.ext), tt.location+" "+tt.ext)
+	}
+}
@@ -1,11 +1,51 @@
 package config
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
 )
 
+// RemoteOptions configures how remote configs are downloaded
+type RemoteOptions struct {
+	// CacheDir is the directory where remote configs are cached. If empty, remote configs are not cached.
+	CacheDir string
+	// Timeout is the timeout for downloading a remote config. If it is not positive, there is no timeout.
+	Timeout time.Duration
+	// Offline only uses cached remote configs, and never downloads them
+	Offline bool
+}
+
+// Remote are the options used to download all remote configs
+var Remote = RemoteOptions{
+	Timeout: 30 * time.Second,
+}
+
+// ErrNoCachedConfig is returned in offline mode when a remote config hasn't been cached
+var ErrNoCachedConfig = errors.New("remote config is not cached")
+
+// DefaultCacheDir returns the directory where remote configs are cached by default,
+// or an empty string if the user doesn't have a cache directory
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "language-checker")
+}
+
 // isValidUrl tests a string to determine if it is a valid URL or not
 func isValidURL(toTest string) bool {
 	_, err := url.ParseRequestURI(toTest)
@@ -20,3 +60,180 @@ func isValidURL(toTest string) bool {
 	log.Debug().Str("remoteConfig", toTest).Msg("Valid URL for remote config.")
 	return true
 }
+
+// gets the remote config from the url provided and returns config.
+// The url may pin the sha256 checksum of the config with a fragment, such as https://example.com/langcheck.yaml#sha256=...
+func loadRemoteConfig(url string) (c Config, err error) {
+	body, err := readRemoteConfig(url)
+	if err != nil {
+		return c, err
+	}
+	return c, yaml.Unmarshal(body, &c)
+}
+
+// readRemoteConfig returns the content of the remote config, after checking its sha256 pin
+func readRemoteConfig(url string) ([]byte, error) {
+	url, pin := splitPin(url)
+
+	body, err := downloadRemoteConfig(url, pin)
+	if err != nil {
+		return nil, err
+	}
+	// the cached config is also checked, in case it was modified
+	if err := checkPin(url, body, pin); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// checkPin returns an error if the sha256 checksum of the body isn't pin
+func checkPin(url string, body []byte, pin string) error {
+	if pin == "" {
+		return nil
+	}
+	sum := sha256.Sum256(body)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, pin) {
+		return fmt.Errorf("sha256 of remote config %s is %s, expected %s", url, actual, pin)
+	}
+	return nil
+}
+
+// downloadRemoteConfig returns the body of the remote config, which is cached with its ETag.
+// The cached config is used if it hasn't changed, or if it can't be downloaded because of
+// a network or server error. A downloaded config is only cached if its checksum matches the pin.
+func downloadRemoteConfig(url, pin string) ([]byte, error) {
+	cached, etag := readCachedConfig(url)
+	if Remote.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoCachedConfig, url)
+		}
+		log.Debug().Str("url", url).Msg("Using cached remote config in offline mode")
+		return cached, nil
+	}
+
+	log.Debug().Str("url", url).Msg("Downloading file from")
+	ctx := context.Background()
+	if Remote.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, Remote.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return useCachedConfig(url, cached, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return useCachedConfig(url, cached, err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		log.Debug().Str("url", url).Str("etag", etag).Msg("Remote config not modified, using cached copy")
+		return cached, nil
+	}
+
+	// only parse response body if it is in the response is in the 2xx range
+	statusOK := resp.StatusCode >= 200 && resp.StatusCode <= 299
+	if !statusOK {
+		err = fmt.Errorf("unable to download remote config from url. Response code: %v. Response body: %c", resp.StatusCode, body)
+		if resp.StatusCode >= 500 {
+			return useCachedConfig(url, cached, err)
+		}
+		return nil, err
+	}
+
+	log.Debug().Int("HTTP Response Status:", resp.StatusCode).Msg("Valid URL Response")
+	if err := checkPin(url, body, pin); err != nil {
+		return nil, err
+	}
+	writeCachedConfig(url, body, resp.Header.Get("ETag"))
+	return body, nil
+}
+
+// useCachedConfig returns the cached config if there is one, or err if there isn't
+func useCachedConfig(url string, cached []byte, err error) ([]byte, error) {
+	if cached == nil {
+		return nil, err
+	}
+	log.Warn().Str("url", url).Err(err).Msg("Unable to download remote config, using cached copy")
+	return cached, nil
+}
+
+// splitPin returns the url without the sha256 fragment, and the checksum in the fragment
+func splitPin(url string) (string, string) {
+	i := strings.LastIndex(url, "#sha256=")
+	if i < 0 {
+		return url, ""
+	}
+	return url[:i], url[i+len("#sha256="):]
+}
+
+// cachePath returns the path of the cached config, and the path of its ETag
+func cachePath(url string) (string, string) {
+	sum := sha256.Sum256([]byte(url))
+	name := filepath.Join(Remote.CacheDir, "config", hex.EncodeToString(sum[:]))
+	return name + ".yaml", name + ".etag"
+}
+
+// readCachedConfig returns the cached config and its ETag, or nil if it isn't cached
+func readCachedConfig(url string) ([]byte, string) {
+	if Remote.CacheDir == "" {
+		return nil, ""
+	}
+	configPath, etagPath := cachePath(url)
+	body, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, ""
+	}
+	etag, _ := os.ReadFile(etagPath)
+	return body, string(etag)
+}
+
+// writeCachedConfig caches the config. Errors are only logged, since the cache is only an optimization.
+func writeCachedConfig(url string, body []byte, etag string) {
+	if Remote.CacheDir == "" {
+		return
+	}
+	configPath, etagPath := cachePath(url)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		log.Debug().Err(err).Msg("Unable to create remote config cache")
+		return
+	}
+	// the config is written last, so a partially written cache is never used with the wrong ETag
+	_ = os.Remove(configPath)
+	if err := writeFileAtomic(etagPath, []byte(etag)); err != nil {
+		log.Debug().Err(err).Msg("Unable to cache remote config")
+		return
+	}
+	if err := writeFileAtomic(configPath, body); err != nil {
+		log.Debug().Err(err).Msg("Unable to cache remote config")
+	}
+}
+
+// writeFileAtomic writes the file by renaming a temporary file, so it is never partially written
+func writeFileAtomic(filename string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
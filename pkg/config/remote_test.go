@@ -1,7 +1,14 @@
 package config
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +39,148 @@ func Test_isValidURL(t *testing.T) {
 		assert.False(t, boolResponse)
 	})
 }
+
+// testRemote sets the remote options for the test, with a temporary cache directory
+func testRemote(t *testing.T) {
+	original := Remote
+	Remote = RemoteOptions{CacheDir: t.TempDir(), Timeout: time.Second}
+	t.Cleanup(func() { Remote = original })
+}
+
+const remoteConfig = "rules:\n  - name: rule1\n    terms:\n      - rule1\n"
+
+func remoteConfigSum() string {
+	sum := sha256.Sum256([]byte(remoteConfig))
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_loadRemoteConfig(t *testing.T) {
+	t.Run("etag", func(t *testing.T) {
+		testRemote(t)
+		var requests, notModified int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(remoteConfig))
+		}))
+		defer server.Close()
+
+		for i := 0; i < 2; i++ {
+			c, err := loadRemoteConfig(server.URL)
+			assert.NoError(t, err)
+			assert.Len(t, c.Rules, 1)
+		}
+		assert.Equal(t, 2, requests)
+		assert.Equal(t, 1, notModified)
+	})
+
+	t.Run("offline fallback", func(t *testing.T) {
+		testRemote(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(remoteConfig))
+		}))
+		_, err := loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+		server.Close()
+
+		// the server is no longer available
+		c, err := loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+		assert.Len(t, c.Rules, 1)
+
+		Remote.Offline = true
+		c, err = loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+		assert.Len(t, c.Rules, 1)
+
+		_, err = loadRemoteConfig(server.URL + "/not-cached.yaml")
+		assert.ErrorIs(t, err, ErrNoCachedConfig)
+	})
+
+	t.Run("server error fallback", func(t *testing.T) {
+		testRemote(t)
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(remoteConfig))
+		}))
+		defer server.Close()
+
+		_, err := loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+
+		status = http.StatusBadGateway
+		c, err := loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+		assert.Len(t, c.Rules, 1)
+
+		// client errors are not a blip, and are always returned
+		status = http.StatusNotFound
+		_, err = loadRemoteConfig(server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		testRemote(t)
+		Remote.Timeout = 10 * time.Millisecond
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer server.Close()
+		defer close(done)
+
+		_, err := loadRemoteConfig(server.URL)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("sha256 pin", func(t *testing.T) {
+		testRemote(t)
+		body := remoteConfig
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		c, err := loadRemoteConfig(server.URL + "#sha256=" + remoteConfigSum())
+		assert.NoError(t, err)
+		assert.Len(t, c.Rules, 1)
+
+		body = remoteConfig + "  - name: rule2\n    terms:\n      - rule2\n"
+		_, err = loadRemoteConfig(server.URL + "#sha256=" + remoteConfigSum())
+		assert.EqualError(t, err, fmt.Sprintf("sha256 of remote config %s is %s, expected %s", server.URL, fmt.Sprintf("%x", sha256.Sum256([]byte(body))), remoteConfigSum()))
+
+		// the config that didn't match the pin is not cached
+		cached, _ := readCachedConfig(server.URL)
+		assert.Equal(t, remoteConfig, string(cached))
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		testRemote(t)
+		Remote.CacheDir = ""
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(remoteConfig))
+		}))
+		_, err := loadRemoteConfig(server.URL)
+		assert.NoError(t, err)
+		server.Close()
+
+		_, err = loadRemoteConfig(server.URL)
+		assert.Error(t, err)
+	})
+}
+
+func Test_splitPin(t *testing.T) {
+	url, pin := splitPin("https://example.com/langcheck.yaml#sha256=abc123")
+	assert.Equal(t, "https://example.com/langcheck.yaml", url)
+	assert.Equal(t, "abc123", pin)
+
+	url, pin = splitPin("https://example.com/langcheck.yaml")
+	assert.Equal(t, "https://example.com/langcheck.yaml", url)
+	assert.Equal(t, "", pin)
+}
@@ -0,0 +1,364 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a problem with a config file, found by Validate
+type Problem struct {
+	Filename string
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.Filename, p.Line, p.Column, p.Message)
+}
+
+// validSeverities are the severities that NewSeverity understands. Any other severity is treated as info.
+var validSeverities = []string{"error", "warning", "warn", "info"}
+
+// Validate strictly checks the config file at the path or URL, and returns the problems it found.
+// Unlike NewConfig, which ignores unknown fields and treats invalid severities as info, every field must be
+// known and every value must be valid. Rules are also checked against the default rules, unless
+// disableDefaultRules is set, and against the rules of any configs in extends.
+// An error is returned if the config file can't be read or isn't valid YAML.
+func Validate(location string, disableDefaultRules bool) ([]Problem, error) {
+	body, err := readConfigFrom(location)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	v := &validator{filename: location}
+	if len(doc.Content) == 0 {
+		// an empty config is valid
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	v.checkFields(root, reflect.TypeOf(Config{}), "config")
+	if root.Kind == yaml.MappingNode {
+		base := v.baseRules(root, disableDefaultRules)
+		v.checkRules(mappingValue(root, "rules"), base)
+		v.checkCategories(root, base)
+		if n := mappingValue(root, "scope"); n != nil {
+			v.checkScope(n)
+		}
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		if v.problems[i].Line == v.problems[j].Line {
+			return v.problems[i].Column < v.problems[j].Column
+		}
+		return v.problems[i].Line < v.problems[j].Line
+	})
+	return v.problems, nil
+}
+
+// readConfigFrom returns the content of the config at the path or URL
+func readConfigFrom(location string) ([]byte, error) {
+	if isValidURL(location) {
+		return readRemoteConfig(location)
+	}
+	return os.ReadFile(location)
+}
+
+type validator struct {
+	filename string
+	problems []Problem
+}
+
+func (v *validator) add(n *yaml.Node, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		Filename: v.filename,
+		Line:     n.Line,
+		Column:   n.Column,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+var yamlv2Unmarshaler = reflect.TypeOf((*yamlv2.Unmarshaler)(nil)).Elem()
+
+// checkFields checks that the node can be decoded into a value of type t,
+// and that every field of a mapping is a field of the struct
+func (v *validator) checkFields(n *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	// scalars that decode themselves, such as severities, are checked separately
+	if t.Kind() != reflect.Struct && reflect.PointerTo(t).Implements(yamlv2Unmarshaler) {
+		return
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			v.add(n, "%s must be a mapping", path)
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				v.add(key, "unknown field %q in %s%s", key.Value, path, suggest(key.Value, fields))
+				continue
+			}
+			v.checkFields(value, field.Type, path+"."+key.Value)
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			v.add(n, "%s must be a list", path)
+			return
+		}
+		for i, item := range n.Content {
+			v.checkFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		if n.Kind != yaml.ScalarNode {
+			v.add(n, "%s must be a %s", path, t.Kind())
+			return
+		}
+		if t.Kind() == reflect.Bool && n.ShortTag() != "!!bool" {
+			v.add(n, "%s must be true or false, not %q", path, n.Value)
+		}
+	}
+}
+
+// yamlFields returns the fields of the struct, by the name they have in yaml
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// suggest returns a suggestion of the field that was probably meant, if there is one that is close enough
+func suggest(name string, fields map[string]reflect.StructField) string {
+	best, bestDistance := "", 3
+	for field := range fields {
+		if d := levenshtein(name, field); d < bestDistance || (d == bestDistance && field < best) {
+			best, bestDistance = field, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// baseRules returns the rules that the rules in the config override or add to:
+// the default rules, and the rules of the configs it extends
+func (v *validator) baseRules(root *yaml.Node, disableDefaultRules bool) []*rule.Rule {
+	var base []*rule.Rule
+	if !disableDefaultRules {
+		base = rule.DefaultRules
+	}
+
+	n := mappingValue(root, "extends")
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return base
+	}
+	var c Config
+	for _, ext := range n.Content {
+		c.Extends = append(c.Extends, ext.Value)
+	}
+	extended, err := c.extend(v.filename, []string{extendsKey(v.filename)})
+	if err != nil {
+		v.add(n, "%s", err)
+		return base
+	}
+	return mergeRules(base, extended.Rules)
+}
+
+// checkRules checks for rules without names or terms, duplicate names, invalid severities and scopes,
+// and terms that are also terms of other rules
+func (v *validator) checkRules(rules *yaml.Node, base []*rule.Rule) {
+	if rules == nil || rules.Kind != yaml.SequenceNode {
+		return
+	}
+
+	names := map[string]*yaml.Node{}
+	for _, n := range rules.Content {
+		if name := mappingValue(n, "name"); name != nil {
+			names[name.Value] = name
+		}
+	}
+
+	// terms of the base rules that aren't overridden, by their lowercase term
+	terms := map[string]string{}
+	for _, r := range base {
+		if _, ok := names[r.Name]; ok {
+			continue
+		}
+		for _, t := range r.Terms {
+			terms[strings.ToLower(t)] = fmt.Sprintf("rule %q", r.Name)
+		}
+	}
+
+	seen := map[string]*yaml.Node{}
+	for _, n := range rules.Content {
+		if n.Kind != yaml.MappingNode {
+			continue
+		}
+
+		nameNode := mappingValue(n, "name")
+		if nameNode == nil || strings.TrimSpace(nameNode.Value) == "" {
+			v.add(n, "rule is missing a name")
+			nameNode = n
+		} else if first, ok := seen[nameNode.Value]; ok {
+			v.add(nameNode, "duplicate rule name %q, first defined on line %d", nameNode.Value, first.Line)
+		} else {
+			seen[nameNode.Value] = nameNode
+		}
+		name := nameNode.Value
+
+		termsNode := mappingValue(n, "terms")
+		if termsNode == nil || termsNode.Kind != yaml.SequenceNode || len(termsNode.Content) == 0 {
+			if !hasRule(base, name) {
+				v.add(nameNode, "rule %q has no terms, so it never has findings", name)
+			}
+		} else {
+			for _, t := range termsNode.Content {
+				term := strings.ToLower(strings.TrimSpace(t.Value))
+				if term == "" {
+					v.add(t, "rule %q has an empty term", name)
+					continue
+				}
+				if other, ok := terms[term]; ok {
+					v.add(t, "term %q of rule %q is also a term of %s, so it is reported twice", t.Value, name, other)
+					continue
+				}
+				terms[term] = fmt.Sprintf("rule %q on line %d", name, t.Line)
+			}
+		}
+
+		if sev := mappingValue(n, "severity"); sev != nil && !isValidSeverity(sev.Value) {
+			v.add(sev, "invalid severity %q, must be one of: error, warning, info", sev.Value)
+		}
+		if options := mappingValue(n, "options"); options != nil {
+			if scope := mappingValue(options, "scope"); scope != nil {
+				v.checkScope(scope)
+			}
+		}
+	}
+}
+
+// checkCategories checks that every category in exclude_categories is a category of at least one rule
+func (v *validator) checkCategories(root *yaml.Node, base []*rule.Rule) {
+	exclude := mappingValue(root, "exclude_categories")
+	if exclude == nil || exclude.Kind != yaml.SequenceNode {
+		return
+	}
+
+	categories := map[string]bool{}
+	for _, r := range base {
+		for _, c := range r.Options.Categories {
+			categories[c] = true
+		}
+	}
+	if rules := mappingValue(root, "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+		for _, n := range rules.Content {
+			if cats := mappingValue(mappingValue(n, "options"), "categories"); cats != nil {
+				for _, c := range cats.Content {
+					categories[c.Value] = true
+				}
+			}
+		}
+	}
+
+	for _, c := range exclude.Content {
+		if !categories[c.Value] {
+			v.add(c, "unknown category %q, no rule has this category", c.Value)
+		}
+	}
+}
+
+func (v *validator) checkScope(n *yaml.Node) {
+	if _, err := rule.NewScope(n.Value); err != nil {
+		v.add(n, "%s", err)
+	}
+}
+
+func isValidSeverity(s string) bool {
+	for _, sev := range validSeverities {
+		if s == sev {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRule(rules []*rule.Rule, name string) bool {
+	for _, r := range rules {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingValue returns the value of the key in the mapping node, or nil if the key isn't in the mapping
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func problemStrings(problems []Problem) []string {
+	var s []string
+	for _, p := range problems {
+		s = append(s, p.String())
+	}
+	return s
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		problems, err := Validate("testdata/good.yaml", false)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		problems, err := Validate("testdata/validate/invalid.yaml", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`testdata/validate/invalid.yaml:7:5: unknown field "severty" in config.rules[0], did you mean "severity"?`,
+			`testdata/validate/invalid.yaml:11:9: rule "rule2" has an empty term`,
+			`testdata/validate/invalid.yaml:12:15: invalid severity "critical", must be one of: error, warning, info`,
+			`testdata/validate/invalid.yaml:14:14: invalid scope "docstrings", must be one of: all, comments, strings, comments_and_strings`,
+			`testdata/validate/invalid.yaml:15:7: unknown field "word_boundry" in config.rules[1].options, did you mean "word_boundary"?`,
+			`testdata/validate/invalid.yaml:16:11: duplicate rule name "rule1", first defined on line 2`,
+			`testdata/validate/invalid.yaml:19:11: rule "rule3" has no terms, so it never has findings`,
+			`testdata/validate/invalid.yaml:23:9: term "Rule2" of rule "rule4" is also a term of rule "rule2" on line 10, so it is reported twice`,
+			`testdata/validate/invalid.yaml:24:9: term "whitelist" of rule "rule4" is also a term of rule "whitelist", so it is reported twice`,
+			`testdata/validate/invalid.yaml:28:5: rule is missing a name`,
+			`testdata/validate/invalid.yaml:31:15: config.include_note must be true or false, not "maybe"`,
+			`testdata/validate/invalid.yaml:34:5: unknown category "cultrual", no rule has this category`,
+			`testdata/validate/invalid.yaml:35:1: unknown field "ignore_file" in config, did you mean "ignore_files"?`,
+		}, problemStrings(problems))
+	})
+
+	t.Run("disable default rules", func(t *testing.T) {
+		problems, err := Validate("testdata/validate/invalid.yaml", true)
+		assert.NoError(t, err)
+		assert.Len(t, problems, 12)
+		for _, p := range problems {
+			assert.NotContains(t, p.Message, `"whitelist"`)
+		}
+	})
+
+	t.Run("extends", func(t *testing.T) {
+		problems, err := Validate("testdata/validate/extends.yaml", true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`testdata/validate/extends.yaml:9:9: term "rule2" of rule "rule5" is also a term of rule "rule2", so it is reported twice`,
+		}, problemStrings(problems))
+	})
+
+	t.Run("not a mapping", func(t *testing.T) {
+		problems, err := Validate("../../testdata/invalid.yaml", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"../../testdata/invalid.yaml:1:1: config must be a mapping"}, problemStrings(problems))
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "langcheck.yaml")
+		assert.NoError(t, os.WriteFile(filename, []byte("rules:\n  - name: [rule1\n"), 0o600))
+		_, err := Validate(filename, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := Validate("testdata/validate/missing.yaml", false)
+		assert.Error(t, err)
+	})
+}
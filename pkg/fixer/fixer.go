@@ -0,0 +1,232 @@
+package fixer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jdstrand/language-checker/pkg/printer"
+	"github.com/jdstrand/language-checker/pkg/result"
+
+	"github.com/rs/zerolog/log"
+)
+
+// diffContext is the number of unchanged lines shown around each change in a unified diff
+const diffContext = 3
+
+// Fixer replaces findings in files with the first alternative of the rule that found them.
+// It satisfies the printer.Printer interface, so it can be used in place of a printer
+// when parsing paths.
+type Fixer struct {
+	writer io.Writer
+	dryRun bool
+	// unfixed is the number of files with findings that weren't all fixed
+	unfixed int
+}
+
+// NewFixer returns a new Fixer. If dryRun is true, files are not modified, and
+// a unified diff of the changes that would be made is written to w instead.
+func NewFixer(w io.Writer, dryRun bool) *Fixer {
+	return &Fixer{writer: w, dryRun: dryRun}
+}
+
+// compile-time check that Fixer satisfies the Printer interface
+var _ printer.Printer = (*Fixer)(nil)
+
+type replacement struct {
+	start, end int
+	text       string
+}
+
+func (f *Fixer) PrintSuccessExitMessage() bool {
+	return true
+}
+
+func (f *Fixer) Start() {
+}
+
+func (f *Fixer) End() {
+}
+
+// Print rewrites the file in FileResults, replacing each finding with the first
+// alternative of its rule. Findings in the file path, findings for rules without
+// alternatives, and findings that no longer match the file content are skipped.
+func (f *Fixer) Print(fs *result.FileResults) error {
+	fixed, err := f.fix(fs)
+	if fixed < len(fs.Results) {
+		f.unfixed++
+	}
+	return err
+}
+
+// Unfixed returns the number of files with findings that weren't all fixed.
+// Nothing is fixed in a dry run, so every file with findings is unfixed.
+func (f *Fixer) Unfixed() int {
+	return f.unfixed
+}
+
+// fix fixes the findings in the file, and returns the number of findings that were fixed
+func (f *Fixer) fix(fs *result.FileResults) (int, error) {
+	content, err := os.ReadFile(fs.Filename)
+	if err != nil {
+		return 0, err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	fixes := map[int][]replacement{}
+
+	for _, r := range fs.Results {
+		lr, ok := r.(result.LineResult)
+		if !ok || len(lr.Rule.Alternatives) == 0 {
+			continue
+		}
+
+		idx := lr.StartPosition.Line - 1
+		start, end := lr.StartPosition.Column, lr.EndPosition.Column
+		if idx < 0 || idx >= len(lines) || end > len(lines[idx]) || lines[idx][start:end] != lr.Finding {
+			log.Debug().
+				Str("file", fs.Filename).
+				Int("line", lr.StartPosition.Line).
+				Str("finding", lr.Finding).
+				Msg("skipping fix, finding no longer matches file")
+			continue
+		}
+
+		fixes[idx] = append(fixes[idx], replacement{
+			start: start,
+			end:   end,
+			text:  MatchCase(lr.Finding, lr.Rule.Alternatives[0]),
+		})
+	}
+
+	if len(fixes) == 0 {
+		return 0, nil
+	}
+
+	fixed := make([]string, len(lines))
+	copy(fixed, lines)
+	count := 0
+	for idx, rs := range fixes {
+		var n int
+		fixed[idx], n = applyReplacements(lines[idx], rs)
+		count += n
+	}
+
+	if f.dryRun {
+		fmt.Fprint(f.writer, unifiedDiff(fs.Filename, lines, fixed))
+		return 0, nil
+	}
+
+	info, err := os.Stat(fs.Filename)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(fs.Filename, []byte(strings.Join(fixed, "")), info.Mode().Perm()); err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(f.writer, "%s: fixed %d finding(s)\n", fs.Filename, count)
+	return count, nil
+}
+
+// applyReplacements applies the replacements to the line, skipping any
+// replacement that overlaps with a replacement before it.
+// It returns the new line and the number of replacements applied.
+func applyReplacements(line string, rs []replacement) (string, int) {
+	sort.Slice(rs, func(i, j int) bool { return rs[i].start < rs[j].start })
+
+	var b strings.Builder
+	prev := 0
+	applied := 0
+	for _, r := range rs {
+		if r.start < prev {
+			continue
+		}
+		b.WriteString(line[prev:r.start])
+		b.WriteString(r.text)
+		prev = r.end
+		applied++
+	}
+	b.WriteString(line[prev:])
+	return b.String(), applied
+}
+
+// MatchCase returns replacement with the casing style of original applied to it.
+// UPPER and Title casing are preserved, anything else returns replacement unchanged.
+func MatchCase(original, replacement string) string {
+	if strings.ToUpper(original) == original && strings.ToLower(original) != original {
+		return strings.ToUpper(replacement)
+	}
+
+	first, _ := utf8.DecodeRuneInString(original)
+	if unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(replacement)
+		return string(unicode.ToUpper(r)) + replacement[size:]
+	}
+
+	return replacement
+}
+
+// unifiedDiff returns a unified diff between the before and after lines of filename.
+// Since fixes only replace text within a line, both slices always have the same length.
+func unifiedDiff(filename string, before, after []string) string {
+	var changed []int
+	for i := range before {
+		if before[i] != after[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "--- a/%s\n+++ b/%s\n", filename, filename)
+
+	for i := 0; i < len(changed); {
+		// group changes that are close enough to share context into a single hunk
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*diffContext {
+			j++
+		}
+
+		start := changed[i] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changed[j] + diffContext + 1
+		if end > len(before) {
+			end = len(before)
+		}
+		// a trailing empty element is left by SplitAfter when the file ends with a newline
+		if end == len(before) && before[end-1] == "" {
+			end--
+		}
+
+		fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for k := start; k < end; k++ {
+			if before[k] == after[k] {
+				writeDiffLine(buf, " ", before[k])
+				continue
+			}
+			writeDiffLine(buf, "-", before[k])
+			writeDiffLine(buf, "+", after[k])
+		}
+
+		i = j + 1
+	}
+
+	return buf.String()
+}
+
+func writeDiffLine(buf *bytes.Buffer, prefix, line string) {
+	buf.WriteString(prefix)
+	buf.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		buf.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -0,0 +1,152 @@
+package fixer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchCase(t *testing.T) {
+	tests := []struct {
+		original    string
+		replacement string
+		expected    string
+	}{
+		{"whitelist", "allowlist", "allowlist"},
+		{"Whitelist", "allowlist", "Allowlist"},
+		{"WHITELIST", "allowlist", "ALLOWLIST"},
+		{"White-List", "allowlist", "Allowlist"},
+		{"whiteList", "allowlist", "allowlist"},
+		{"MASTER/SLAVE", "leader/follower", "LEADER/FOLLOWER"},
+		{"123", "allowlist", "allowlist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.original, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchCase(tt.original, tt.replacement))
+		})
+	}
+}
+
+func TestApplyReplacements(t *testing.T) {
+	got, n := applyReplacements("abc def ghi", []replacement{
+		{start: 8, end: 11, text: "GHI"},
+		{start: 0, end: 3, text: "ABC"},
+		// overlaps with the first replacement, so it is skipped
+		{start: 1, end: 5, text: "xx"},
+	})
+	assert.Equal(t, "ABC def GHI", got)
+	assert.Equal(t, 2, n)
+}
+
+func TestFixer_Print(t *testing.T) {
+	t.Run("fix", func(t *testing.T) {
+		f := newFile(t, "this whitelist is fine\nno findings\nWHITELIST and Whitelist\n")
+
+		buf := new(bytes.Buffer)
+		fixer := NewFixer(buf, false)
+		assert.NoError(t, fixer.Print(findResults(t, f)))
+		assert.Equal(t, f+": fixed 3 finding(s)\n", buf.String())
+		assert.Equal(t, 0, fixer.Unfixed())
+
+		got, err := os.ReadFile(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "this allowlist is fine\nno findings\nALLOWLIST and Allowlist\n", string(got))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		content := "this whitelist is fine\n1\n2\n3\n4\n5\n6\n7\n8\nwhitelist"
+		f := newFile(t, content)
+
+		buf := new(bytes.Buffer)
+		fixer := NewFixer(buf, true)
+		assert.NoError(t, fixer.Print(findResults(t, f)))
+		assert.Equal(t, 1, fixer.Unfixed())
+
+		expected := "--- a/" + f + "\n+++ b/" + f + "\n" +
+			"@@ -1,4 +1,4 @@\n" +
+			"-this whitelist is fine\n" +
+			"+this allowlist is fine\n" +
+			" 1\n 2\n 3\n" +
+			"@@ -7,4 +7,4 @@\n" +
+			" 6\n 7\n 8\n" +
+			"-whitelist\n\\ No newline at end of file\n" +
+			"+allowlist\n\\ No newline at end of file\n"
+		assert.Equal(t, expected, buf.String())
+
+		// file is not modified
+		got, err := os.ReadFile(f)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(got))
+	})
+
+	t.Run("no alternatives", func(t *testing.T) {
+		f := newFile(t, "this whitelist is fine\n")
+		fs := findResults(t, f)
+		r := rule.TestRule
+		r.Alternatives = nil
+		for i := range fs.Results {
+			lr := fs.Results[i].(result.LineResult)
+			lr.Rule = &r
+			fs.Results[i] = lr
+		}
+
+		buf := new(bytes.Buffer)
+		fixer := NewFixer(buf, false)
+		assert.NoError(t, fixer.Print(fs))
+		assert.Equal(t, "", buf.String())
+		assert.Equal(t, 1, fixer.Unfixed())
+	})
+
+	t.Run("file changed since parsing", func(t *testing.T) {
+		f := newFile(t, "this whitelist is fine\n")
+		fs := findResults(t, f)
+		assert.NoError(t, os.WriteFile(f, []byte("this was already changed\n"), 0o644))
+
+		buf := new(bytes.Buffer)
+		assert.NoError(t, NewFixer(buf, false).Print(fs))
+		assert.Equal(t, "", buf.String())
+	})
+
+	t.Run("path result", func(t *testing.T) {
+		f := newFile(t, "nothing to see here\n")
+		fs := &result.FileResults{Filename: f}
+		for _, pr := range result.MatchPath(&rule.TestRule, "whitelist.txt") {
+			fs.Results = append(fs.Results, pr)
+		}
+		assert.Len(t, fs.Results, 1)
+
+		buf := new(bytes.Buffer)
+		fixer := NewFixer(buf, false)
+		assert.NoError(t, fixer.Print(fs))
+		assert.Equal(t, "", buf.String())
+		assert.Equal(t, 1, fixer.Unfixed())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		assert.Error(t, NewFixer(new(bytes.Buffer), false).Print(&result.FileResults{Filename: "missing.file"}))
+	})
+}
+
+func newFile(t *testing.T, content string) string {
+	f := filepath.Join(t.TempDir(), "file.txt")
+	assert.NoError(t, os.WriteFile(f, []byte(content), 0o644))
+	return f
+}
+
+func findResults(t *testing.T, filename string) *result.FileResults {
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+
+	fs := &result.FileResults{Filename: filename}
+	for i, line := range strings.Split(string(content), "\n") {
+		fs.Results = append(fs.Results, result.FindResults(&rule.TestRule, filename, line, i+1)...)
+	}
+	return fs
+}
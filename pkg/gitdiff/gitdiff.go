@@ -0,0 +1,205 @@
+package gitdiff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// Diff contains the lines that were added or modified relative to a git base
+type Diff struct {
+	root  string
+	files map[string]*File
+}
+
+// File contains the lines of a file that were added or modified
+type File struct {
+	// new is true if the file did not exist in the base, so all of its lines are changes
+	new   bool
+	lines map[int]bool
+}
+
+// NewDiff returns the lines changed in the git repository found at root.
+// If staged is true, only changes in the index are considered, otherwise changes
+// in the working tree (including untracked files) are considered.
+// If base is empty, changes are relative to HEAD when staged, or to the index otherwise.
+func NewDiff(root, base string, staged bool) (*Diff, error) {
+	args := []string{
+		"-c", "core.quotePath=false",
+		"diff", "--unified=0", "--no-color", "--no-ext-diff",
+		"--src-prefix=a/", "--dst-prefix=b/",
+	}
+	if staged {
+		args = append(args, "--cached")
+	}
+	if base != "" {
+		args = append(args, base)
+	}
+	args = append(args, "--")
+
+	out, err := git(root, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := Parse(root, bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+
+	if !staged {
+		// untracked files aren't included in git diff, but every line in them is new
+		out, err := git(root, "-c", "core.quotePath=false", "ls-files", "--others", "--exclude-standard", "-z")
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range strings.Split(string(out), "\x00") {
+			if f != "" {
+				d.files[f] = &File{new: true}
+			}
+		}
+	}
+
+	log.Debug().Str("base", base).Bool("staged", staged).Int("files", len(d.files)).Msg("loaded git diff")
+	return d, nil
+}
+
+func git(root string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = root
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Parse parses a unified diff generated with --unified=0 and returns the lines
+// that were added or modified. Filenames in the diff must be relative to root.
+func Parse(root string, r io.Reader) (*Diff, error) {
+	d := &Diff{
+		root:  root,
+		files: map[string]*File{},
+	}
+
+	var (
+		current *File
+		newFile bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = nil
+			newFile = false
+		case strings.HasPrefix(line, "--- "):
+			newFile = headerName(line, "--- ") == "/dev/null"
+		case strings.HasPrefix(line, "+++ "):
+			name := headerName(line, "+++ ")
+			if name == "/dev/null" {
+				// deleted file, nothing to check
+				current = nil
+				continue
+			}
+			name, err := unquote(name)
+			if err != nil {
+				return nil, err
+			}
+			current = &File{new: newFile, lines: map[int]bool{}}
+			d.files[strings.TrimPrefix(name, "b/")] = current
+		case strings.HasPrefix(line, "@@ "):
+			if current == nil {
+				continue
+			}
+			m := hunkHeaderRegex.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header: %q", line)
+			}
+			start, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+			for i := start; i < start+count; i++ {
+				current.lines[i] = true
+			}
+		}
+	}
+
+	return d, scanner.Err()
+}
+
+// headerName returns the filename of a ---/+++ header line.
+// git ends the line with a tab when the filename contains a space.
+func headerName(line, prefix string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(line, prefix), "\t")
+}
+
+// unquote removes the quoting git applies to filenames with unusual characters
+func unquote(name string) (string, error) {
+	if !strings.HasPrefix(name, `"`) {
+		return name, nil
+	}
+	return strconv.Unquote(name)
+}
+
+// relative returns filename relative to the root of the repository, with forward slashes
+func (d *Diff) relative(filename string) string {
+	abs, err := filepath.Abs(filepath.FromSlash(filename))
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	rel, err := filepath.Rel(d.root, abs)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// File returns the changes of the file, or nil if it has no changes.
+// The path is only resolved once, so the lines of a file should be checked with the returned File.
+func (d *Diff) File(filename string) *File {
+	return d.files[d.relative(filename)]
+}
+
+// HasFile returns true if the file has any changes
+func (d *Diff) HasFile(filename string) bool {
+	return d.File(filename) != nil
+}
+
+// IsNewFile returns true if the file did not exist in the base
+func (d *Diff) IsNewFile(filename string) bool {
+	return d.File(filename).IsNew()
+}
+
+// ContainsLine returns true if the line number (1 based) was added or modified in the file
+func (d *Diff) ContainsLine(filename string, line int) bool {
+	return d.File(filename).ContainsLine(line)
+}
+
+// IsNew returns true if the file did not exist in the base
+func (f *File) IsNew() bool {
+	return f != nil && f.new
+}
+
+// ContainsLine returns true if the line number (1 based) was added or modified
+func (f *File) ContainsLine(line int) bool {
+	return f != nil && (f.new || f.lines[line])
+}
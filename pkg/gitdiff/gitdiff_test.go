@@ -0,0 +1,158 @@
+package gitdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDiff = `diff --git a/modified.txt b/modified.txt
+index 01b4515..d0b6fdb 100644
+--- a/modified.txt
++++ b/modified.txt
+@@ -2,0 +3,2 @@ some context
++added line
++another added line
+@@ -10 +12 @@ more context
+-old line
++new line
+@@ -20,3 +22,0 @@
+-removed
+-removed
+-removed
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++new file
+diff --git a/deleted.txt b/deleted.txt
+deleted file mode 100644
+index e69de29..0000000
+--- a/deleted.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-deleted file
+diff --git "a/quoted\tname.txt" "b/quoted\tname.txt"
+index 01b4515..d0b6fdb 100644
+--- "a/quoted\tname.txt"
++++ "b/quoted\tname.txt"
+@@ -1 +1 @@
+-old
++new
+diff --git a/with space.txt b/with space.txt
+index 01b4515..d0b6fdb 100644
+--- a/with space.txt	
++++ b/with space.txt	
+@@ -1 +1 @@
+-old
++new
+`
+
+func TestParse(t *testing.T) {
+	root, err := os.Getwd()
+	assert.NoError(t, err)
+
+	d, err := Parse(root, strings.NewReader(testDiff))
+	assert.NoError(t, err)
+
+	assert.True(t, d.HasFile("modified.txt"))
+	assert.False(t, d.IsNewFile("modified.txt"))
+	assert.False(t, d.ContainsLine("modified.txt", 2))
+	assert.True(t, d.ContainsLine("modified.txt", 3))
+	assert.True(t, d.ContainsLine("modified.txt", 4))
+	assert.False(t, d.ContainsLine("modified.txt", 5))
+	assert.True(t, d.ContainsLine("modified.txt", 12))
+	assert.False(t, d.ContainsLine("modified.txt", 22))
+
+	assert.True(t, d.HasFile("new.txt"))
+	assert.True(t, d.IsNewFile("new.txt"))
+	assert.True(t, d.ContainsLine("new.txt", 1))
+
+	assert.False(t, d.HasFile("deleted.txt"))
+	assert.False(t, d.HasFile("missing.txt"))
+	assert.False(t, d.ContainsLine("missing.txt", 1))
+
+	assert.True(t, d.ContainsLine("quoted\tname.txt", 1))
+	assert.True(t, d.ContainsLine("with space.txt", 1))
+
+	// absolute paths are relative to the root
+	assert.True(t, d.ContainsLine(filepath.Join(root, "modified.txt"), 3))
+
+	f := d.File(filepath.Join(root, "modified.txt"))
+	assert.False(t, f.IsNew())
+	assert.True(t, f.ContainsLine(3))
+	assert.False(t, f.ContainsLine(5))
+	assert.True(t, d.File("new.txt").IsNew())
+
+	// files without changes have no lines
+	f = d.File("missing.txt")
+	assert.Nil(t, f)
+	assert.False(t, f.IsNew())
+	assert.False(t, f.ContainsLine(1))
+}
+
+func TestParse_InvalidHunk(t *testing.T) {
+	_, err := Parse("", strings.NewReader("--- a/file\n+++ b/file\n@@ invalid @@\n"))
+	assert.Error(t, err)
+}
+
+func TestNewDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	runGit(t, root, "init", "-q")
+	writeFile(t, root, "file.txt", "one\ntwo\nthree\n")
+	writeFile(t, root, "with space.txt", "one\n")
+	runGit(t, root, "add", "file.txt", "with space.txt")
+	runGit(t, root, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	writeFile(t, root, "file.txt", "one\n2\nthree\nfour\n")
+	writeFile(t, root, "with space.txt", "1\n")
+	writeFile(t, root, "staged.txt", "staged\n")
+	runGit(t, root, "add", "staged.txt")
+	writeFile(t, root, "untracked.txt", "untracked\n")
+
+	t.Run("working tree", func(t *testing.T) {
+		d, err := NewDiff(root, "HEAD", false)
+		assert.NoError(t, err)
+		assert.False(t, d.ContainsLine(filepath.Join(root, "file.txt"), 1))
+		assert.True(t, d.ContainsLine(filepath.Join(root, "file.txt"), 2))
+		assert.False(t, d.ContainsLine(filepath.Join(root, "file.txt"), 3))
+		assert.True(t, d.ContainsLine(filepath.Join(root, "file.txt"), 4))
+		assert.True(t, d.ContainsLine(filepath.Join(root, "with space.txt"), 1))
+		assert.True(t, d.IsNewFile(filepath.Join(root, "staged.txt")))
+		assert.True(t, d.IsNewFile(filepath.Join(root, "untracked.txt")))
+	})
+
+	t.Run("staged", func(t *testing.T) {
+		d, err := NewDiff(root, "", true)
+		assert.NoError(t, err)
+		assert.False(t, d.HasFile(filepath.Join(root, "file.txt")))
+		assert.True(t, d.IsNewFile(filepath.Join(root, "staged.txt")))
+		assert.False(t, d.HasFile(filepath.Join(root, "untracked.txt")))
+	})
+
+	t.Run("invalid ref", func(t *testing.T) {
+		_, err := NewDiff(root, "does-not-exist", false)
+		assert.Error(t, err)
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
@@ -0,0 +1,365 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/jdstrand/language-checker/pkg/fixer"
+	"github.com/jdstrand/language-checker/pkg/parser"
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+	"github.com/jdstrand/language-checker/pkg/util"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrExitWithoutShutdown is returned by Run if the client asks the server to exit
+// without asking it to shut down first
+var ErrExitWithoutShutdown = errors.New("exit notification received before shutdown request")
+
+const source = "language-checker"
+
+// Server is a Language Server Protocol server that checks documents opened in an editor
+// for findings, and publishes them as diagnostics. Document contents are checked from
+// memory, so unsaved changes are checked as they are typed.
+type Server struct {
+	parser  *parser.Parser
+	version string
+
+	reader *bufio.Reader
+	writer io.Writer
+	mu     sync.Mutex
+
+	docs     map[string]*document
+	shutdown bool
+}
+
+type document struct {
+	lines   []string
+	results []result.Result
+}
+
+// NewServer returns a new Server that reads requests from r and writes responses to w,
+// checking documents with the rules in p
+func NewServer(p *parser.Parser, version string, r io.Reader, w io.Writer) *Server {
+	return &Server{
+		parser:  p,
+		version: version,
+		reader:  bufio.NewReader(r),
+		writer:  w,
+		docs:    map[string]*document{},
+	}
+}
+
+// Run handles messages until the client asks the server to exit, or the input is closed
+func (s *Server) Run() error {
+	for {
+		data, err := s.readMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()})
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+
+		res, rerr := s.handle(&msg)
+		// notifications don't have an ID, and never get a response
+		if msg.ID != nil {
+			s.reply(msg.ID, res, rerr)
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (interface{}, *responseError) {
+	log.Debug().Str("method", msg.Method).Msg("lsp message")
+
+	switch msg.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    textDocumentSyncKindFull,
+				},
+				CodeActionProvider: codeActionOptions{
+					CodeActionKinds: []string{codeActionKindQuickFix},
+				},
+			},
+			ServerInfo: serverInfo{Name: source, Version: s.version},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		s.check(params.TextDocument.URI, params.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		// Only full document sync is supported, so the last change is the full content
+		if n := len(params.ContentChanges); n > 0 && params.ContentChanges[n-1].Range == nil {
+			s.check(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		delete(s.docs, params.TextDocument.URI)
+		s.publish(params.TextDocument.URI, []diagnostic{})
+		return nil, nil
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.codeActions(params), nil
+	}
+
+	if msg.ID == nil {
+		// unsupported notifications, such as initialized, can be ignored
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+}
+
+// check finds results in the document text and publishes them as diagnostics
+func (s *Server) check(uri, text string) {
+	filename := relativeFilename(uriToFilename(uri))
+	doc := &document{lines: strings.Split(text, "\n")}
+	s.docs[uri] = doc
+
+	if s.ignored(filename) {
+		log.Debug().Str("file", filename).Str("reason", "ignored file").Msg("skipping")
+		s.publish(uri, []diagnostic{})
+		return
+	}
+
+	fs, err := s.parser.ParseReader(filename, strings.NewReader(text))
+	if err != nil {
+		log.Error().Err(err).Str("uri", uri).Msg("unable to check document")
+		return
+	}
+	doc.results = fs.Results
+
+	diagnostics := make([]diagnostic, 0, len(fs.Results))
+	for _, r := range fs.Results {
+		diagnostics = append(diagnostics, doc.diagnostic(r))
+	}
+	s.publish(uri, diagnostics)
+}
+
+func (s *Server) ignored(filename string) bool {
+	if s.parser.Ignorer == nil {
+		return false
+	}
+	return s.parser.Ignorer.Match(filename, false)
+}
+
+// relativeFilename returns the filename relative to the current directory, like the paths the CLI checks,
+// so the directories above it aren't checked for findings. Files outside the current directory are unchanged.
+func relativeFilename(filename string) string {
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, filename); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return filename
+}
+
+// codeActions returns a quick fix for each alternative of every result in the requested range
+func (s *Server) codeActions(params codeActionParams) []codeAction {
+	actions := []codeAction{}
+
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return actions
+	}
+
+	for _, r := range doc.results {
+		lr, ok := r.(result.LineResult)
+		if !ok {
+			// findings in the filename can't be fixed by editing the document
+			continue
+		}
+
+		d := doc.diagnostic(r)
+		if !overlaps(d.Range, params.Range) {
+			continue
+		}
+
+		for _, alt := range lr.Rule.Alternatives {
+			alt = fixer.MatchCase(lr.Finding, alt)
+			actions = append(actions, codeAction{
+				Title:       fmt.Sprintf("Replace %s with %s", util.MarkdownCodify(lr.Finding), util.MarkdownCodify(alt)),
+				Kind:        codeActionKindQuickFix,
+				Diagnostics: []diagnostic{d},
+				Edit: workspaceEdit{
+					Changes: map[string][]textEdit{
+						params.TextDocument.URI: {{Range: d.Range, NewText: alt}},
+					},
+				},
+			})
+		}
+	}
+
+	return actions
+}
+
+func (doc *document) diagnostic(r result.Result) diagnostic {
+	return diagnostic{
+		Range: lspRange{
+			Start: doc.position(r.GetStartPosition().Line, r.GetStartPosition().Column),
+			End:   doc.position(r.GetEndPosition().Line, r.GetEndPosition().Column),
+		},
+		Severity: calculateDiagnosticSeverity(r.GetSeverity()),
+		Code:     r.GetRuleName(),
+		Source:   source,
+		Message:  r.Reason(),
+	}
+}
+
+// position converts a 1 based line and byte offset column into an LSP position,
+// which is 0 based with the character offset counted in UTF-16 code units
+func (doc *document) position(line, column int) position {
+	idx := line - 1
+	if idx < 0 || idx >= len(doc.lines) {
+		return position{}
+	}
+
+	text := doc.lines[idx]
+	if column > len(text) {
+		column = len(text)
+	}
+
+	character := 0
+	for _, r := range text[:column] {
+		character += utf16.RuneLen(r)
+	}
+	return position{Line: idx, Character: character}
+}
+
+func calculateDiagnosticSeverity(s rule.Severity) int {
+	switch s {
+	case rule.SevError:
+		return severityError
+	case rule.SevWarn:
+		return severityWarning
+	}
+	return severityInformation
+}
+
+func overlaps(a, b lspRange) bool {
+	return !before(a.End, b.Start) && !before(b.End, a.Start)
+}
+
+func before(a, b position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+func uriToFilename(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	if u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	if u.Opaque != "" {
+		return u.Opaque
+	}
+	return u.Path
+}
+
+func (s *Server) publish(uri string, diagnostics []diagnostic) {
+	s.write(notification{
+		JSONRPC: jsonrpcVersion,
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
+
+func (s *Server) reply(id *json.RawMessage, res interface{}, rerr *responseError) {
+	s.write(response{JSONRPC: jsonrpcVersion, ID: id, Result: res, Error: rerr})
+}
+
+func (s *Server) write(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to encode lsp message")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(data), data); err != nil {
+		log.Error().Err(err).Msg("unable to write lsp message")
+	}
+}
+
+// readMessage reads a single message with its base protocol headers
+func (s *Server) readMessage() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" && length == -1 {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
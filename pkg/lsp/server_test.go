@@ -0,0 +1,195 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/parser"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testServer(t *testing.T, input string) []map[string]interface{} {
+	t.Helper()
+
+	r := rule.TestRule
+	e := rule.TestErrorRule
+	p := parser.NewParser([]*rule.Rule{&r, &e}, nil)
+
+	out := new(bytes.Buffer)
+	err := NewServer(p, "test", bytes.NewBufferString(input), out).Run()
+	assert.NoError(t, err)
+
+	return readMessages(t, out)
+}
+
+func frame(msgs ...string) string {
+	var s string
+	for _, msg := range msgs {
+		s += fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	return s
+}
+
+func readMessages(t *testing.T, r io.Reader) []map[string]interface{} {
+	t.Helper()
+
+	s := &Server{reader: bufio.NewReader(r)}
+	var msgs []map[string]interface{}
+	for {
+		data, err := s.readMessage()
+		if err == io.EOF {
+			return msgs
+		}
+		assert.NoError(t, err)
+
+		var msg map[string]interface{}
+		assert.NoError(t, json.Unmarshal(data, &msg))
+		msgs = append(msgs, msg)
+	}
+}
+
+const didOpen = `{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///tmp/test.txt","languageId":"plaintext","version":1,"text":"this is fine\nthe 🎉 whitelist and the slave"}}}`
+
+func TestServer_Initialize(t *testing.T) {
+	msgs := testServer(t, frame(
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	))
+
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, float64(1), msgs[0]["id"])
+	assert.Equal(t, map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync":   map[string]interface{}{"openClose": true, "change": float64(1)},
+			"codeActionProvider": map[string]interface{}{"codeActionKinds": []interface{}{"quickfix"}},
+		},
+		"serverInfo": map[string]interface{}{"name": "language-checker", "version": "test"},
+	}, msgs[0]["result"])
+	assert.Equal(t, float64(2), msgs[1]["id"])
+	assert.Nil(t, msgs[1]["result"])
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	p := parser.NewParser([]*rule.Rule{}, nil)
+	err := NewServer(p, "test", bytes.NewBufferString(frame(`{"jsonrpc":"2.0","method":"exit"}`)), io.Discard).Run()
+	assert.ErrorIs(t, err, ErrExitWithoutShutdown)
+}
+
+func TestServer_MethodNotFound(t *testing.T) {
+	msgs := testServer(t, frame(`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{}}`))
+
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, map[string]interface{}{
+		"code":    float64(codeMethodNotFound),
+		"message": "method not found: textDocument/hover",
+	}, msgs[0]["error"])
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	msgs := testServer(t, frame(
+		didOpen,
+		`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"file:///tmp/test.txt","version":2},"contentChanges":[{"text":"this is fine now"}]}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didClose","params":{"textDocument":{"uri":"file:///tmp/test.txt"}}}`,
+	))
+
+	assert.Len(t, msgs, 3)
+	for _, msg := range msgs {
+		assert.Equal(t, "textDocument/publishDiagnostics", msg["method"])
+	}
+
+	params := msgs[0]["params"].(map[string]interface{})
+	assert.Equal(t, "file:///tmp/test.txt", params["uri"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"range": map[string]interface{}{
+				// the emoji is 4 bytes, but 2 UTF-16 code units
+				"start": map[string]interface{}{"line": float64(1), "character": float64(7)},
+				"end":   map[string]interface{}{"line": float64(1), "character": float64(16)},
+			},
+			"severity": float64(severityWarning),
+			"code":     "whitelist",
+			"source":   "language-checker",
+			"message":  "`whitelist` may be insensitive, use `allowlist` instead",
+		},
+		map[string]interface{}{
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": float64(1), "character": float64(25)},
+				"end":   map[string]interface{}{"line": float64(1), "character": float64(30)},
+			},
+			"severity": float64(severityError),
+			"code":     "slave",
+			"source":   "language-checker",
+			"message":  "`slave` may be insensitive, use `follower` instead",
+		},
+	}, params["diagnostics"])
+
+	for _, msg := range msgs[1:] {
+		assert.Equal(t, []interface{}{}, msg["params"].(map[string]interface{})["diagnostics"])
+	}
+}
+
+func TestServer_DiagnosticsRelativePath(t *testing.T) {
+	// the directories above the current directory aren't checked for findings
+	dir := filepath.Join(t.TempDir(), "whitelist", "project")
+	assert.NoError(t, os.MkdirAll(dir, 0o755))
+	t.Chdir(dir)
+
+	open := func(filename string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file://%s","languageId":"plaintext","version":1,"text":"this is fine"}}}`, filepath.ToSlash(filepath.Join(dir, filename)))
+	}
+	msgs := testServer(t, frame(open("fine.txt"), open("slave.txt")))
+
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, []interface{}{}, msgs[0]["params"].(map[string]interface{})["diagnostics"])
+	diagnostics := msgs[1]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, "slave", diagnostics[0].(map[string]interface{})["code"])
+	}
+}
+
+func TestServer_CodeAction(t *testing.T) {
+	msgs := testServer(t, frame(
+		didOpen,
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/codeAction","params":{"textDocument":{"uri":"file:///tmp/test.txt"},"range":{"start":{"line":1,"character":27},"end":{"line":1,"character":27}},"context":{"diagnostics":[]}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/codeAction","params":{"textDocument":{"uri":"file:///tmp/test.txt"},"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":4}},"context":{"diagnostics":[]}}}`,
+	))
+
+	assert.Len(t, msgs, 3)
+
+	actions := msgs[1]["result"].([]interface{})
+	assert.Len(t, actions, 1)
+
+	action := actions[0].(map[string]interface{})
+	assert.Equal(t, "quickfix", action["kind"])
+	assert.Equal(t, "Replace `slave` with `follower`", action["title"])
+
+	edit := action["edit"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"file:///tmp/test.txt": []interface{}{
+			map[string]interface{}{
+				"range": map[string]interface{}{
+					"start": map[string]interface{}{"line": float64(1), "character": float64(25)},
+					"end":   map[string]interface{}{"line": float64(1), "character": float64(30)},
+				},
+				"newText": "follower",
+			},
+		},
+	}, edit["changes"])
+
+	assert.Equal(t, []interface{}{}, msgs[2]["result"])
+}
+
+func TestUriToFilename(t *testing.T) {
+	assert.Equal(t, "/tmp/some file.txt", uriToFilename("file:///tmp/some%20file.txt"))
+	assert.Equal(t, "Untitled-1", uriToFilename("untitled:Untitled-1"))
+}
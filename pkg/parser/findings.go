@@ -5,11 +5,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/jdstrand/language-checker/pkg/gitdiff"
 	"github.com/jdstrand/language-checker/pkg/result"
 	"github.com/jdstrand/language-checker/pkg/rule"
+	"github.com/jdstrand/language-checker/pkg/tokenizer"
 	"github.com/jdstrand/language-checker/pkg/util"
 
 	"github.com/rs/zerolog/log"
@@ -40,9 +43,19 @@ func (p *Parser) generateFileFindings(file *os.File) (*result.FileResults, error
 		Filename: filename,
 	}
 
-	// Check for findings in the filename itself
-	for _, pathResult := range result.MatchPathRules(p.Rules, file.Name()) {
-		results.Results = append(results.Results, pathResult)
+	var changes *gitdiff.File
+	if p.Diff != nil {
+		if changes = p.Diff.File(filename); changes == nil {
+			log.Debug().Str("file", filename).Str("reason", "no changes in git diff").Msg("skipping")
+			return results, nil
+		}
+	}
+
+	// Check for findings in the filename itself, which is only considered a change if the file is new
+	if p.Diff == nil || changes.IsNew() {
+		for _, pathResult := range result.MatchPathRules(p.pathRules(filename), file.Name()) {
+			results.Results = append(results.Results, pathResult)
+		}
 	}
 
 	// Don't check file content if it's not a text file or file is empty
@@ -51,17 +64,88 @@ func (p *Parser) generateFileFindings(file *os.File) (*result.FileResults, error
 		return results, nil
 	}
 
-	reader := bufio.NewReader(file)
+	if err := p.generateLineFindings(results, file); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ParseReader returns the findings in the content read from r, as if it were the content of filename.
+// This allows checking content that is not on disk, such as an unsaved editor buffer.
+func (p *Parser) ParseReader(filename string, r io.Reader) (*result.FileResults, error) {
+	results := &result.FileResults{
+		Filename: filepath.ToSlash(filename),
+	}
+
+	for _, pathResult := range result.MatchPathRules(p.pathRules(filename), filename) {
+		results.Results = append(results.Results, pathResult)
+	}
+
+	if err := p.generateLineFindings(results, r); err != nil {
+		return nil, err
+	}
+	sort.Sort(results)
+	return results, nil
+}
+
+// generateLineFindings reads each line from r and adds results of places where rules are broken to results
+func (p *Parser) generateLineFindings(results *result.FileResults, r io.Reader) error {
+	filename := results.Filename
+	reader := bufio.NewReader(r)
+
+	// Comments and strings are only found if a rule needs them, since most rules check the entire file.
+	// Files in languages that aren't supported are checked in full by every rule.
+	var scanner *tokenizer.Scanner
+	if lang := tokenizer.ForFilename(filename); lang != nil && p.hasScopedRules() {
+		scanner = lang.NewScanner()
+	}
+
+	excluded := p.excludedRules(filename)
 
 	var ignoreNextLineText string
+	blocks := blockIgnores{check: p.checkIgnore}
 	line := 1
 
+	// the changes of the file are looked up once, rather than for every line
+	var changes *gitdiff.File
+	if p.Diff != nil {
+		changes = p.Diff.File(filename)
+	}
+
+	var ignores *ignoreTracker
+	if p.Ignorer != nil && p.ReportUnusedIgnores {
+		ignores = &ignoreTracker{changes: changes}
+	}
+
 Loop:
 	for {
 		switch text, err := reader.ReadString('\n'); {
 		case err == nil || (err == io.EOF && text != ""):
 			text = strings.TrimSuffix(text, "\n")
 
+			// every line has to be scanned, since comments and strings can span multiple lines
+			var regions []tokenizer.Region
+			if scanner != nil {
+				regions = scanner.Line(text)
+			}
+
+			// Directives that begin or end a region apply to the lines they are on
+			if p.Ignorer != nil {
+				blocks.next(text, filename, line)
+			}
+			if ignores != nil {
+				ignores.add(text, line)
+			}
+
+			// findResults returns the findings of the rule in the line, limited to the scope of the rule
+			findResults := func(r *rule.Rule) []result.Result {
+				lineResults := result.FindResults(r, results.Filename, text, line)
+				if scanner != nil && r.Scope() != rule.ScopeAll {
+					lineResults = filterScope(r.Scope(), regions, lineResults)
+				}
+				return lineResults
+			}
+
 			// Store current line's langcheckignore text if ignoring next line
 			if rule.IsDirectiveOnlyLine(text) {
 				ignoreNextLineText = text
@@ -69,28 +153,63 @@ Loop:
 				continue
 			}
 
-			for _, r := range p.Rules {
+			if p.Diff != nil && !changes.ContainsLine(line) {
+				ignoreNextLineText = ""
+				line++
+				continue
+			}
+
+			// Only check the rules that have a term in the line, which is much faster than checking every rule
+			for _, r := range p.candidateRules(text) {
+				if excluded[r] {
+					continue
+				}
+
 				if p.Ignorer != nil {
-					if ignoreNextLineText == "" && r.CanIgnoreLine(text) {
+					if blocks.ignores(r) {
 						log.Debug().
 							Str("rule", r.Name).
 							Str("file", filename).
 							Int("line", line).
-							Msg("ignoring via in-line")
+							Msg("ignoring via block")
 						continue
-					} else if r.CanIgnoreLine(ignoreNextLineText) {
-						// Check current rule against prev line's next-line langcheckignore text (if applicable)
+					}
+
+					// A next-line langcheckignore on the previous line takes precedence over an in-line langcheckignore
+					directive, directiveLine, via := text, line, "in-line"
+					if ignoreNextLineText != "" {
+						// next-line directives are always on the previous line
+						directive, directiveLine, via = ignoreNextLineText, line-1, "next-line"
+					}
+
+					if r.CanIgnoreLine(directive) {
+						lineResults := findResults(r)
+						if ignores != nil && len(lineResults) > 0 {
+							ignores.use(r, directiveLine)
+						}
+
+						err := p.checkRuleIgnore(r, directive)
+						if err == nil {
+							log.Debug().
+								Str("rule", r.Name).
+								Str("file", filename).
+								Int("line", line).
+								Msg("ignoring via " + via)
+							continue
+						}
+
 						log.Debug().
 							Str("rule", r.Name).
 							Str("file", filename).
 							Int("line", line).
-							Msg("ignoring via next-line")
+							Str("reason", err.Error()).
+							Msg("not ignoring via " + via)
+						results.Results = append(results.Results, lineResults...)
 						continue
 					}
 				}
 
-				lineResults := result.FindResults(r, results.Filename, text, line)
-				results.Results = append(results.Results, lineResults...)
+				results.Results = append(results.Results, findResults(r)...)
 			}
 
 			ignoreNextLineText = ""
@@ -98,9 +217,152 @@ Loop:
 		case err == io.EOF:
 			break Loop
 		case err != nil:
-			return nil, err
+			return err
 		}
 	}
 
-	return results, nil
+	if len(blocks.file) > 0 {
+		filtered := results.Results[:0]
+		for _, res := range results.Results {
+			if blocks.ignoresFile(res.GetRule()) {
+				log.Debug().
+					Str("rule", res.GetRuleName()).
+					Str("file", filename).
+					Int("line", res.GetStartPosition().Line).
+					Msg("ignoring via file")
+				continue
+			}
+			filtered = append(filtered, res)
+		}
+		results.Results = filtered
+	}
+
+	if ignores != nil {
+		results.Results = append(results.Results, ignores.results(p, filename, excluded)...)
+	}
+
+	return nil
+}
+
+// blockIgnores tracks the regions of a file ignored with langcheckignore:begin and langcheckignore:end,
+// and the rules ignored for the entire file with langcheckignore:file
+type blockIgnores struct {
+	// regions is a stack of the begin directives of the regions that include the current line
+	regions []rule.Directive
+	// ends is the number of regions that end on the current line
+	ends int
+	file []rule.Directive
+	// check returns an error if a directive is not allowed to ignore findings
+	check func(rule.IgnoreAttributes) error
+}
+
+// next updates the regions for the next line of the file
+func (b *blockIgnores) next(text, filename string, line int) {
+	if b.ends > 0 {
+		b.regions = b.regions[:max(len(b.regions)-b.ends, 0)]
+		b.ends = 0
+	}
+
+	for _, d := range rule.ParseDirectives(text) {
+		if err := b.check(d.IgnoreAttributes); err != nil && d.Kind != rule.DirectiveEnd {
+			log.Debug().
+				Strs("rules", d.Rules).
+				Str("file", filename).
+				Int("line", line).
+				Str("reason", err.Error()).
+				Msg("not ignoring via block or file")
+		}
+
+		switch d.Kind {
+		case rule.DirectiveBegin:
+			b.regions = append(b.regions, d)
+		case rule.DirectiveEnd:
+			// the line with the end directive is still part of the region
+			b.ends++
+		case rule.DirectiveFile:
+			b.file = append(b.file, d)
+		}
+	}
+}
+
+// ignores denotes if the current line is in a region that ignores the rule
+func (b *blockIgnores) ignores(r *rule.Rule) bool {
+	for _, d := range b.regions {
+		if d.Ignores(r) && b.check(d.IgnoreAttributes) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoresFile denotes if the rule is ignored for the entire file
+func (b *blockIgnores) ignoresFile(r *rule.Rule) bool {
+	for _, d := range b.file {
+		if d.Ignores(r) && b.check(d.IgnoreAttributes) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// filterScope returns the results that are entirely within the comments and/or strings of the line,
+// depending on the scope
+func filterScope(scope rule.Scope, regions []tokenizer.Region, results []result.Result) []result.Result {
+	filtered := results[:0]
+	for _, r := range results {
+		kind, ok := tokenizer.Within(regions, r.GetStartPosition().Column, r.GetEndPosition().Column)
+		if !ok {
+			continue
+		}
+		if (kind == tokenizer.Comment && scope.Comments()) || (kind == tokenizer.String && scope.Strings()) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func (p *Parser) hasScopedRules() bool {
+	for _, r := range p.Rules {
+		if r.Scope() != rule.ScopeAll {
+			return true
+		}
+	}
+	return false
+}
+
+// pathRules returns the rules that check the filename, which excludes rules that only check comments or strings,
+// and rules that don't apply to the file
+func (p *Parser) pathRules(filename string) []*rule.Rule {
+	if !p.hasScopedRules() && !p.hasPathRules() {
+		return p.Rules
+	}
+
+	rules := make([]*rule.Rule, 0, len(p.Rules))
+	for _, r := range p.Rules {
+		if r.Scope() == rule.ScopeAll && r.AppliesToPath(filename) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func (p *Parser) hasPathRules() bool {
+	for _, r := range p.Rules {
+		if r.HasPaths() {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedRules returns the rules that don't check the file, because of their include_paths or exclude_paths
+func (p *Parser) excludedRules(filename string) map[*rule.Rule]bool {
+	excluded := map[*rule.Rule]bool{}
+	for _, r := range p.Rules {
+		if !r.AppliesToPath(filename) {
+			log.Debug().Str("rule", r.Name).Str("file", filename).Str("reason", "rule paths").Msg("skipping rule")
+			excluded[r] = true
+		}
+	}
+	return excluded
 }
@@ -1,12 +1,16 @@
 package parser
 
 import (
+	"fmt"
 	"go/token"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/jdstrand/language-checker/pkg/gitdiff"
 	"github.com/jdstrand/language-checker/pkg/result"
 	"github.com/jdstrand/language-checker/pkg/rule"
 
@@ -165,3 +169,427 @@ func TestGenerateFileFindingsNewLineIgnores(t *testing.T) {
 		})
 	}
 }
+
+// Tests for block and file langcheckignore
+func TestGenerateFileFindingsBlockIgnores(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		matches int
+	}{
+		{"block", "#langcheckignore:begin\n whitelist\n whitelist\n#langcheckignore:end\n whitelist", 1},
+		{"block with rule", "#langcheckignore:begin rule=whitelist\n whitelist\n slave\n#langcheckignore:end", 1},
+		{"block with other rule", "#langcheckignore:begin rule=slave\n whitelist\n#langcheckignore:end", 1},
+		{"block includes directive lines", "whitelist #langcheckignore:begin\nwhitelist #langcheckignore:end\nwhitelist", 1},
+		{"unterminated block", "whitelist\n#langcheckignore:begin rule=whitelist\n whitelist\n whitelist", 1},
+		{"nested blocks", "#langcheckignore:begin rule=whitelist\n#langcheckignore:begin rule=slave\n whitelist slave\n#langcheckignore:end\n whitelist slave\n#langcheckignore:end\n whitelist slave", 3},
+		{"unmatched end", "#langcheckignore:end\n whitelist", 1},
+		{"file", "whitelist\n#langcheckignore:file\n whitelist slave", 0},
+		{"file with rule", "whitelist\n#langcheckignore:file rule=whitelist\n whitelist slave", 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := newFile(t, tc.content)
+			assert.NoError(t, err)
+
+			p, err := testParser()
+			assert.NoError(t, err)
+			whitelist, slave := rule.TestRule, rule.TestErrorRule
+			p = NewParser([]*rule.Rule{&whitelist, &slave}, p.Ignorer)
+			res, err := p.generateFileFindingsFromFilename(f.Name())
+			assert.NoError(t, err)
+			assert.Len(t, res.Results, tc.matches)
+		})
+	}
+
+	t.Run("file ignores filename findings", func(t *testing.T) {
+		f, err := newFileWithPrefix(t, "whitelist-", "# langcheckignore:file rule=whitelist\n")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		res, err := p.generateFileFindingsFromFilename(f.Name())
+		assert.NoError(t, err)
+		assert.Len(t, res.Results, 0)
+	})
+}
+
+// Tests for reporting langcheckignore directives that don't ignore anything
+func TestGenerateFileFindingsUnusedIgnores(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		// expected are the reasons of the unused ignore results
+		expected []string
+	}{
+		{"used in-line", "whitelist #langcheckignore:rule=whitelist", nil},
+		{"used next-line", "#langcheckignore:rule=whitelist\nwhitelist", nil},
+		{"unused in-line", "fine #langcheckignore:rule=whitelist", []string{"`whitelist` has no findings to ignore, so the langcheckignore directive can be removed"}},
+		{"unused next-line", "#langcheckignore:rule=whitelist\nfine\nwhitelist", []string{"`whitelist` has no findings to ignore, so the langcheckignore directive can be removed"}},
+		{"unknown rule", "whitelist #langcheckignore:rule=whitelsit", []string{"`whitelsit` is not a known rule, so the langcheckignore directive has no effect"}},
+		{"partially used", "whitelist #langcheckignore:rule=whitelist,slave", []string{"`slave` has no findings to ignore, so the langcheckignore directive can be removed"}},
+		{"in-line with next-line", "#langcheckignore:rule=whitelist\nwhitelist #langcheckignore:rule=whitelist", []string{"`whitelist` has no findings to ignore, so the langcheckignore directive can be removed"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := newFile(t, tc.content)
+			assert.NoError(t, err)
+
+			p, err := testParser()
+			assert.NoError(t, err)
+			whitelist, slave := rule.TestRule, rule.TestErrorRule
+			p = NewParser([]*rule.Rule{&whitelist, &slave}, p.Ignorer)
+			p.ReportUnusedIgnores = true
+			res, err := p.generateFileFindingsFromFilename(f.Name())
+			assert.NoError(t, err)
+
+			var reasons []string
+			for _, r := range res.Results {
+				if r.GetRuleName() == result.UnusedIgnoreRule.Name {
+					reasons = append(reasons, r.Reason())
+				}
+			}
+			assert.Equal(t, tc.expected, reasons)
+		})
+	}
+
+	t.Run("position", func(t *testing.T) {
+		f, err := newFile(t, "fine\nfine #langcheckignore:rule=slave,whitelist")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		p.ReportUnusedIgnores = true
+		res, err := p.generateFileFindingsFromFilename(f.Name())
+		assert.NoError(t, err)
+		assert.Len(t, res.Results, 2)
+		assert.Equal(t, 2, res.Results[1].GetStartPosition().Line)
+		assert.Equal(t, 33, res.Results[1].GetStartPosition().Column)
+		assert.Equal(t, 42, res.Results[1].GetEndPosition().Column)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f, err := newFile(t, "fine #langcheckignore:rule=whitelist")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		res, err := p.generateFileFindingsFromFilename(f.Name())
+		assert.NoError(t, err)
+		assert.Len(t, res.Results, 0)
+	})
+}
+
+// Tests for requiring a reason and expiring langcheckignore directives
+func TestGenerateFileFindingsIgnorePolicy(t *testing.T) {
+	tests := []struct {
+		desc          string
+		content       string
+		requireReason bool
+		expire        bool
+		matches       int
+	}{
+		{"reason not required", "whitelist #langcheckignore:rule=whitelist", false, false, 0},
+		{"missing reason", "whitelist #langcheckignore:rule=whitelist", true, false, 1},
+		{"reason", `whitelist #langcheckignore:rule=whitelist reason="protocol"`, true, false, 0},
+		{"next-line missing reason", "#langcheckignore:rule=whitelist\nwhitelist", true, false, 1},
+		{"next-line reason", "#langcheckignore:rule=whitelist reason=\"protocol\"\nwhitelist", true, false, 0},
+		{"expired not enforced", "whitelist #langcheckignore:rule=whitelist until=2000-01-01", false, false, 0},
+		{"expired", "whitelist #langcheckignore:rule=whitelist until=2000-01-01", false, true, 1},
+		{"not expired", "whitelist #langcheckignore:rule=whitelist until=2999-01-01", false, true, 0},
+		{"invalid until", "whitelist #langcheckignore:rule=whitelist until=soon", false, true, 1},
+		{"until in block comment", "whitelist /* langcheckignore:rule=whitelist until=2999-01-01*/", false, true, 0},
+		{"until in html comment", "whitelist <!-- langcheckignore:rule=whitelist until=2999-01-01-->", false, true, 0},
+		{"block until in html comment", "<!-- langcheckignore:begin until=2999-01-01-->\nwhitelist\n<!-- langcheckignore:end -->", false, true, 0},
+		{"reason is not a finding", `fine #langcheckignore:rule=slave reason="whitelist"`, false, false, 0},
+		{"block missing reason", "#langcheckignore:begin\nwhitelist\n#langcheckignore:end", true, false, 1},
+		{"block reason", "#langcheckignore:begin reason=\"generated\"\nwhitelist\n#langcheckignore:end", true, false, 0},
+		{"block expired", "#langcheckignore:begin until=2000-01-01\nwhitelist\n#langcheckignore:end\nwhitelist", false, true, 2},
+		{"file expired", "whitelist\n#langcheckignore:file until=2000-01-01", false, true, 1},
+		{"file not expired", "whitelist\n#langcheckignore:file until=2999-01-01", false, true, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := newFile(t, tc.content)
+			assert.NoError(t, err)
+
+			p, err := testParser()
+			assert.NoError(t, err)
+			p.RequireIgnoreReason = tc.requireReason
+			p.ExpireIgnores = tc.expire
+			res, err := p.generateFileFindingsFromFilename(f.Name())
+			assert.NoError(t, err)
+			assert.Len(t, res.Results, tc.matches)
+		})
+	}
+}
+
+func TestGenerateFileFindingsGitDiff(t *testing.T) {
+	f, err := newFileWithPrefix(t, "whitelist-", "whitelist unchanged\nwhitelist changed\n")
+	assert.NoError(t, err)
+	root, name := filepath.Split(f.Name())
+
+	tests := []struct {
+		desc    string
+		diff    string
+		matches int
+		path    bool
+	}{
+		{"no changes", "", 0, false},
+		{"modified line", "--- a/" + name + "\n+++ b/" + name + "\n@@ -2 +2 @@\n-old\n+whitelist changed\n", 1, false},
+		{"new file", "--- /dev/null\n+++ b/" + name + "\n@@ -0,0 +1,2 @@\n+whitelist unchanged\n+whitelist changed\n", 3, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := testParser()
+			assert.NoError(t, err)
+			p.Diff, err = gitdiff.Parse(root, strings.NewReader(tc.diff))
+			assert.NoError(t, err)
+
+			res, err := p.generateFileFindingsFromFilename(f.Name())
+			assert.NoError(t, err)
+			assert.Len(t, res.Results, tc.matches)
+			if tc.path {
+				assert.Regexp(t, "^Filename finding: ", res.Results[0].Reason())
+			} else if tc.matches > 0 {
+				assert.Equal(t, 2, res.Results[0].GetStartPosition().Line)
+			}
+		})
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	p, err := testParser()
+	assert.NoError(t, err)
+
+	res, err := p.ParseReader("whitelist/unsaved.txt", strings.NewReader("this is fine\nthis has whitelist # langcheckignore:rule=blacklist\nwhitelist # langcheckignore:rule=whitelist\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "whitelist/unsaved.txt", res.Filename)
+	assert.Len(t, res.Results, 2)
+
+	// path results are sorted before line results
+	assert.Equal(t, 1, res.Results[0].GetStartPosition().Column)
+	assert.Equal(t, 2, res.Results[1].GetStartPosition().Line)
+	assert.Equal(t, 9, res.Results[1].GetStartPosition().Column)
+}
+
+func TestParseReaderRulePaths(t *testing.T) {
+	whitelist := rule.TestRule
+	whitelist.SetOptions(rule.Options{IncludePaths: []string{"docs/**"}, ExcludePaths: []string{"docs/generated/**"}})
+	slave := rule.TestErrorRule
+	slave.SetOptions(rule.Options{ExcludePaths: []string{"vendor/redis/**"}})
+	p := NewParser([]*rule.Rule{&whitelist, &slave}, nil)
+
+	tests := []struct {
+		filename string
+		rules    []string
+	}{
+		{"docs/guide.md", []string{"whitelist", "slave"}},
+		{"./docs/api/index.md", []string{"whitelist", "slave"}},
+		{"docs/generated/api.md", []string{"slave"}},
+		{"README.md", []string{"slave"}},
+		{"vendor/redis/conn.go", nil},
+		{"vendor/other/conn.go", []string{"slave"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.filename, func(t *testing.T) {
+			res, err := p.ParseReader(tc.filename, strings.NewReader("the whitelist and the slave\n"))
+			assert.NoError(t, err)
+			var rules []string
+			for _, r := range res.Results {
+				rules = append(rules, r.GetRuleName())
+			}
+			assert.Equal(t, tc.rules, rules)
+		})
+	}
+
+	// the ignores of rules that don't check the file aren't reported as unused
+	tp, err := testParser()
+	assert.NoError(t, err)
+	p.Ignorer, p.ReportUnusedIgnores = tp.Ignorer, true
+	res, err := p.ParseReader("README.md", strings.NewReader("fine #langcheckignore:rule=whitelist,slave\n"))
+	assert.NoError(t, err)
+	if assert.Len(t, res.Results, 1) {
+		assert.Equal(t, "`slave` has no findings to ignore, so the langcheckignore directive can be removed", res.Results[0].Reason())
+	}
+	res, err = p.ParseReader("vendor/redis/conn.go", strings.NewReader("fine #langcheckignore:rule=whitelist,slave\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, res.Results)
+	p.ReportUnusedIgnores = false
+
+	// findings in the filename are also limited to the rule's paths
+	res, err = p.ParseReader("vendor/redis/slave.go", strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Empty(t, res.Results)
+	res, err = p.ParseReader("docs/whitelist.md", strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+}
+
+func TestParseReaderRulePaths_AbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	whitelist := rule.TestRule
+	whitelist.SetOptions(rule.Options{IncludePaths: []string{"docs/**"}})
+	p := NewParser([]*rule.Rule{&whitelist}, nil)
+
+	res, err := p.ParseReader(filepath.Join(dir, "docs", "a.md"), strings.NewReader("the whitelist\n"))
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+
+	res, err = p.ParseReader(filepath.Join(dir, "src", "a.md"), strings.NewReader("the whitelist\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, res.Results)
+}
+
+// benchmarkRules returns the default rules, plus enough custom rules to have n rules
+func benchmarkRules(n int) []*rule.Rule {
+	rules := make([]*rule.Rule, 0, n)
+	for _, r := range rule.DefaultRules {
+		r := *r
+		rules = append(rules, &r)
+	}
+	for i := len(rules); i < n; i++ {
+		r := &rule.Rule{
+			Name:         fmt.Sprintf("custom-%d", i),
+			Terms:        []string{fmt.Sprintf("customterm%d", i), fmt.Sprintf("custom-term-%d", i)},
+			Alternatives: []string{"alternative"},
+			Options:      rule.Options{WordBoundary: i%2 == 0},
+		}
+		rules = append(rules, r)
+	}
+	for _, r := range rules {
+		r.SetRegexp()
+	}
+	return rules
+}
+
+func benchmarkContent(lines int) string {
+	text := []string{
+		"func (s *Server) handle(ctx context.Context, req *Request) (*Response, error) {",
+		"	// check the request against the allowlist before doing anything else",
+		"	if err := s.validate(req); err != nil {",
+		"		return nil, fmt.Errorf(\"invalid request: %w\", err)",
+		"	}",
+		"	replica := s.cluster.Primary().Replicas[0] // was the slave node",
+		"	return s.forward(ctx, replica, req, customterm42)",
+		"}",
+	}
+	var b strings.Builder
+	for i := 0; i < lines; i++ {
+		b.WriteString(text[i%len(text)])
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// perRuleFindings finds results by checking every rule against every line,
+// which is how findings were generated before the Matcher
+func perRuleFindings(rules []*rule.Rule, content string) []result.Result {
+	var results []result.Result
+	for i, text := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		for _, r := range rules {
+			results = append(results, result.FindResults(r, "bench.go", text, i+1)...)
+		}
+	}
+	return results
+}
+
+func TestParseReaderMatchesPerRule(t *testing.T) {
+	rules := benchmarkRules(300)
+	content := benchmarkContent(100)
+
+	p := NewParser(rules, nil)
+	res, err := p.ParseReader("bench.go", strings.NewReader(content))
+	assert.NoError(t, err)
+
+	expected := perRuleFindings(rules, content)
+	assert.NotEmpty(t, expected)
+	sort.Sort(&result.FileResults{Filename: "bench.go", Results: expected})
+	assert.Equal(t, expected, res.Results)
+}
+
+// BenchmarkFindings compares finding results with the Matcher to checking every rule against every line.
+// Run with: go test ./pkg/parser -run ^$ -bench BenchmarkFindings -benchmem
+func BenchmarkFindings(b *testing.B) {
+	for _, n := range []int{len(rule.DefaultRules), 300, 1000} {
+		rules := benchmarkRules(n)
+		content := benchmarkContent(1000)
+
+		b.Run(fmt.Sprintf("matcher/rules=%d", n), func(b *testing.B) {
+			p := NewParser(rules, nil)
+			b.SetBytes(int64(len(content)))
+			for i := 0; i < b.N; i++ {
+				if _, err := p.ParseReader("bench.go", strings.NewReader(content)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("per-rule/rules=%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(content)))
+			for i := 0; i < b.N; i++ {
+				perRuleFindings(rules, content)
+			}
+		})
+	}
+}
+
+func TestGenerateFileFindingsScope(t *testing.T) {
+	content := `// the slave is a whitelist
+/* multi-line comment
+   about the slave */
+func slaveof(whitelist []string) {
+	log.Print("whitelist the slave")
+}
+`
+	tests := []struct {
+		desc     string
+		filename string
+		scope    rule.Scope
+		// expected lines with slave findings
+		expected []int
+	}{
+		{"all", "main.go", rule.ScopeAll, []int{1, 3, 4, 5}},
+		{"comments", "main.go", rule.ScopeComments, []int{1, 3}},
+		{"strings", "main.go", rule.ScopeStrings, []int{5}},
+		{"comments and strings", "main.go", rule.ScopeCommentsAndStrings, []int{1, 3, 5}},
+		{"unsupported language", "main.txt", rule.ScopeComments, []int{1, 3, 4, 5}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			slave := rule.TestErrorRule
+			slave.Options.Scope = &tc.scope
+			whitelist := rule.TestRule
+			p := NewParser([]*rule.Rule{&slave, &whitelist}, nil)
+
+			res, err := p.ParseReader(tc.filename, strings.NewReader(content))
+			assert.NoError(t, err)
+
+			var lines []int
+			whitelists := 0
+			for _, r := range res.Results {
+				if r.GetRuleName() == slave.Name {
+					lines = append(lines, r.GetStartPosition().Line)
+				} else {
+					whitelists++
+				}
+			}
+			assert.Equal(t, tc.expected, lines)
+			// rules without a scope still check the entire file
+			assert.Equal(t, 3, whitelists)
+		})
+	}
+
+	t.Run("filename", func(t *testing.T) {
+		scope := rule.ScopeComments
+		slave := rule.TestErrorRule
+		slave.Options.Scope = &scope
+		p := NewParser([]*rule.Rule{&slave}, nil)
+
+		res, err := p.ParseReader("slave.go", strings.NewReader("package main\n"))
+		assert.NoError(t, err)
+		assert.Len(t, res.Results, 0)
+	})
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdstrand/language-checker/pkg/gitdiff"
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrIgnoreMissingReason is the reason an ignore directive without a reason doesn't ignore findings,
+	// when a reason is required
+	ErrIgnoreMissingReason = errors.New("ignore directive is missing a reason")
+	// ErrIgnoreExpired is the reason an ignore directive doesn't ignore findings after its until date,
+	// when ignores expire
+	ErrIgnoreExpired = errors.New("ignore directive has expired")
+)
+
+// checkIgnore returns an error if an ignore directive with the attributes is not allowed to ignore findings
+func (p *Parser) checkIgnore(a rule.IgnoreAttributes) error {
+	if p.RequireIgnoreReason && a.Reason == "" {
+		return ErrIgnoreMissingReason
+	}
+	if p.ExpireIgnores && a.Expired(time.Now()) {
+		if !a.ValidUntil() {
+			log.Warn().Str("until", a.Until).Msg("ignore directive has an until that isn't a YYYY-MM-DD date, so it has expired")
+		}
+		return ErrIgnoreExpired
+	}
+	return nil
+}
+
+// checkRuleIgnore returns an error if none of the langcheckignore:rule= directives in the line
+// that name the rule are allowed to ignore its findings. The line must name the rule.
+func (p *Parser) checkRuleIgnore(r *rule.Rule, text string) error {
+	var err error
+	for _, ig := range rule.ParseRuleIgnores(text) {
+		if ig.Name != r.Name {
+			continue
+		}
+		if err = p.checkIgnore(ig.IgnoreAttributes); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// ignoreTracker tracks the rules named by the langcheckignore:rule= directives in a file,
+// to report the ones that didn't ignore any findings
+type ignoreTracker struct {
+	ignores []*trackedIgnore
+	// changes are the changes of the file in the git diff, if there is one
+	changes *gitdiff.File
+}
+
+type trackedIgnore struct {
+	rule.RuleIgnore
+	text string
+	// line is the line with the directive, and target is the line with the findings it ignores
+	line   int
+	target int
+	used   bool
+}
+
+// add tracks the rules named by the directives in the line
+func (t *ignoreTracker) add(text string, line int) {
+	target := line
+	if rule.IsDirectiveOnlyLine(text) {
+		target = line + 1
+	}
+	for _, ig := range rule.ParseRuleIgnores(text) {
+		t.ignores = append(t.ignores, &trackedIgnore{RuleIgnore: ig, text: text, line: line, target: target})
+	}
+}
+
+// use marks the directives on the line that name the rule as having ignored a finding
+func (t *ignoreTracker) use(r *rule.Rule, line int) {
+	for _, ig := range t.ignores {
+		if ig.line == line && ig.Name == r.Name {
+			ig.used = true
+		}
+	}
+}
+
+// results returns an IgnoreResult for each tracked rule that didn't ignore a finding,
+// or that isn't one of the parser's rules. The rules in excluded don't check the file,
+// so it's unknown if their directives are used.
+func (t *ignoreTracker) results(p *Parser, filename string, excluded map[*rule.Rule]bool) (rs []result.Result) {
+	for _, ig := range t.ignores {
+		if ig.used || p.isExcluded(ig.Name, excluded) {
+			continue
+		}
+		// the findings of lines that aren't checked are unknown
+		if p.Diff != nil && !t.changes.ContainsLine(ig.target) {
+			continue
+		}
+		rs = append(rs, result.NewIgnoreResult(ig.Name, filename, ig.text, ig.line, ig.Start, ig.End, !p.hasRule(ig.Name)))
+	}
+	return
+}
+
+// isExcluded denotes if the rule with the name is one of the excluded rules
+func (p *Parser) isExcluded(name string, excluded map[*rule.Rule]bool) bool {
+	for r := range excluded {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRule denotes if the parser has a rule with the name
+func (p *Parser) hasRule(name string) bool {
+	for _, r := range p.Rules {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,10 +1,16 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"runtime"
 	"sort"
 	"sync"
 
+	"github.com/jdstrand/language-checker/pkg/baseline"
+	"github.com/jdstrand/language-checker/pkg/gitdiff"
 	"github.com/jdstrand/language-checker/pkg/ignore"
 	"github.com/jdstrand/language-checker/pkg/printer"
 	"github.com/jdstrand/language-checker/pkg/result"
@@ -12,22 +18,40 @@ import (
 	"github.com/jdstrand/language-checker/pkg/util"
 	"github.com/jdstrand/language-checker/pkg/walker"
 
-	env "github.com/caitlinelfring/go-env-default"
 	"github.com/rs/zerolog/log"
 )
 
 // DefaultPath is the default path if no paths are provided
 var DefaultPath = []string{"."}
 
-// TODO: can this be dynamically determined?
-const numWorkers = 20
-
 // Parser parses files and finds lines that break rules
 type Parser struct {
 	Rules   []*rule.Rule
 	Ignorer *ignore.Ignore
+	// Diff, if set, limits findings to the lines that were added or modified in git
+	Diff *gitdiff.Diff
+	// Baseline, if set, filters out findings that already exist in the baseline
+	Baseline *baseline.Baseline
+	// SkipFiles are files that are never checked, whatever the ignore settings are, such as the baseline file
+	SkipFiles []string
+	// Jobs is the number of files that are read in parallel. If it is not positive, the number of CPUs is used.
+	Jobs int
+	// FailFast stops parsing files once a file with findings has been found
+	FailFast bool
+	// Unsorted prints the results of each file as soon as it has been parsed, instead of
+	// waiting until all files have been parsed to print them sorted by filename
+	Unsorted bool
+	// ReportUnusedIgnores adds a result for each rule named by a langcheckignore:rule= directive
+	// that didn't ignore any findings, or that isn't one of the Rules
+	ReportUnusedIgnores bool
+	// RequireIgnoreReason only allows ignore directives with a reason, such as reason="redis protocol",
+	// to ignore findings
+	RequireIgnoreReason bool
+	// ExpireIgnores stops ignore directives from ignoring findings after their until date, such as until=2027-01-01
+	ExpireIgnores bool
 
-	rchan chan result.FileResults
+	matcherOnce sync.Once
+	matcher     *rule.Matcher
 }
 
 // NewParser returns a pointer to a Parser that is used to check for findings
@@ -36,111 +60,221 @@ func NewParser(rules []*rule.Rule, ignorer *ignore.Ignore) *Parser {
 	return &Parser{
 		Rules:   rules,
 		Ignorer: ignorer,
-		rchan:   make(chan result.FileResults),
 	}
 }
 
 // ParsePaths parses all files provided and returns the number of files with findings
 func (p *Parser) ParsePaths(print printer.Printer, paths ...string) int {
+	findings, _ := p.ParsePathsContext(context.Background(), print, paths...)
+	return findings
+}
+
+// ParsePathsContext parses all files provided and returns the number of files with findings.
+// If ctx is cancelled, no new files are read, and the context's error is returned
+// along with the number of files with findings that were printed before that.
+// Errors from printing the results of files, such as failing to write a fixed file, are also returned,
+// joined with the context's error.
+func (p *Parser) ParsePathsContext(ctx context.Context, print printer.Printer, paths ...string) (int, error) {
 	print.Start()
 	defer print.End()
 
+	// files without findings are only printed if the printer reports every file
+	clean := printer.PrintsCleanFiles(print)
+
+	var printErrs []error
+	printResults := func(r *result.FileResults) {
+		if err := print.Print(r); err != nil {
+			log.Debug().Str("file", r.Filename).Err(err).Msg("unable to print results")
+			printErrs = append(printErrs, err)
+		}
+	}
+
 	// data provided through stdin
 	if util.InSlice(os.Stdin.Name(), paths) {
 		r, _ := p.generateFileFindings(os.Stdin)
-		if r.Len() > 0 {
-			print.Print(r)
+		p.filterBaseline(r)
+		if r.Len() > 0 || (clean && r != nil) {
+			printResults(r)
 		}
-		return r.Len()
+		return r.Len(), errors.Join(ctx.Err(), errors.Join(printErrs...))
 	}
 
 	if len(paths) == 0 {
 		paths = DefaultPath
 	}
-	var wg sync.WaitGroup
 
-	done := make(chan bool)
-	defer close(done)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files := p.walkPaths(ctx, paths)
+	rchan := make(chan *result.FileResults)
+
+	jobs := p.jobs()
+	log.Debug().Int("jobs", jobs).Msg("process files")
 
-	for _, path := range paths {
-		wg.Add(1)
-		go func(path string) {
+	var wg sync.WaitGroup
+	wg.Add(jobs)
+	for i := 0; i < jobs; i++ {
+		go func() {
 			defer wg.Done()
-			p.processFindingInPath(path, done)
-		}(path)
+			p.processFiles(ctx, files, rchan, clean)
+		}()
 	}
 
 	go func() {
 		wg.Wait()
-		close(p.rchan)
+		close(rchan)
 	}()
 
+	var sorted []*result.FileResults
 	findings := 0
-	for r := range p.rchan {
+	for r := range rchan {
+		p.filterBaseline(r)
+		if r.Len() == 0 && !clean {
+			continue
+		}
 		sort.Sort(r)
-		print.Print(&r)
+		if p.Unsorted {
+			printResults(r)
+		} else {
+			sorted = append(sorted, r)
+		}
+		if r.Len() == 0 {
+			continue
+		}
 		findings++
+
+		if p.FailFast {
+			log.Debug().Str("file", r.Filename).Msg("stopping after first file with findings")
+			cancel()
+			break
+		}
 	}
-	return findings
-}
 
-func (p *Parser) processFiles(files <-chan string, done chan bool, wg *sync.WaitGroup) {
-	for f := range files {
-		wg.Add(1)
-		go func(f string) {
-			defer wg.Done()
+	// drain any results that were sent before cancelling, so the workers can exit
+	for range rchan {
+	}
 
-			v, _ := p.generateFileFindingsFromFilename(f)
-			if v == nil || len(v.Results) == 0 {
-				return
-			}
-			p.rchan <- *v
-		}(f)
+	// files are parsed in parallel, so sort them to make the output the same on every run
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Filename < sorted[j].Filename
+	})
+	for _, r := range sorted {
+		printResults(r)
 	}
+
+	printErr := errors.Join(printErrs...)
+	if p.FailFast && findings > 0 {
+		return findings, printErr
+	}
+	return findings, errors.Join(ctx.Err(), printErr)
 }
 
-func (p *Parser) processFindingInPath(path string, done chan bool) {
-	var wg sync.WaitGroup
+func (p *Parser) jobs() int {
+	if p.Jobs > 0 {
+		return p.Jobs
+	}
+	return runtime.NumCPU()
+}
 
-	files := p.walkDir(path, done)
+// candidateRules returns the rules that may have findings in the text.
+// The matcher is built the first time it's needed, so Rules can't be changed once parsing has started.
+func (p *Parser) candidateRules(text string) []*rule.Rule {
+	p.matcherOnce.Do(func() {
+		p.matcher = rule.NewMatcher(p.Rules)
+	})
+	return p.matcher.Candidates(text)
+}
 
-	// run parallel, but bounded
-	numWorker := env.GetIntDefault("WORKER_POOL_COUNT", 0)
-	if numWorker > 0 {
-		log.Debug().Str("path", path).Str("type", "bounded").Int("workers", numWorker).Msg("process files")
+func (p *Parser) filterBaseline(r *result.FileResults) {
+	if p.Baseline != nil && r != nil {
+		p.Baseline.Filter(r)
+	}
+}
+
+// processFiles reads files until there are no more files, or ctx is cancelled,
+// and sends the results of each file with findings to rchan, or of every file if clean is true
+func (p *Parser) processFiles(ctx context.Context, files <-chan walkedFile, rchan chan<- *result.FileResults, clean bool) {
+	for f := range files {
+		if ctx.Err() != nil {
+			return
+		}
 
-		wg.Add(numWorkers)
-		for i := 0; i < numWorkers; i++ {
-			go func() {
-				p.processFiles(files, done, &wg)
-				wg.Done()
-			}()
+		v, err := p.generateFileFindingsFromFilename(f.path)
+		if err != nil {
+			log.Debug().Str("file", f.path).Err(err).Msg("unable to read file")
+			continue
+		}
+		// directories are only checked for findings in their names, so they are never clean files
+		if v == nil || (len(v.Results) == 0 && (!clean || f.dir)) {
+			continue
 		}
-	} else {
-		// run parallel unbounded. Potential high memory consumption
-		log.Debug().Str("path", path).Str("type", "parallel").Msg("process files")
 
-		p.processFiles(files, done, &wg)
+		select {
+		case rchan <- v:
+		case <-ctx.Done():
+			return
+		}
 	}
+}
 
-	wg.Wait()
+// walkedFile is a path found by walkPaths
+type walkedFile struct {
+	path string
+	// dir denotes if the path is a directory
+	dir bool
 }
 
-func (p *Parser) walkDir(dirname string, done chan bool) <-chan string {
-	paths := make(chan string)
+// walkPaths walks all paths, sending every file that isn't ignored to the returned channel,
+// until all paths have been walked or ctx is cancelled
+func (p *Parser) walkPaths(ctx context.Context, paths []string) <-chan walkedFile {
+	files := make(chan walkedFile)
 
 	go func() {
-		defer close(paths)
-		_ = walker.Walk(dirname, func(path string, info os.DirEntry) error {
-			if p.Ignorer != nil && p.Ignorer.Match(path, info.IsDir()) {
-				log.Debug().Str("file", path).Str("reason", "ignored file").Msg("skipping")
-				return nil
+		defer close(files)
+		for _, path := range paths {
+			if err := p.walkDir(ctx, path, files); err != nil {
+				return
 			}
+		}
+	}()
 
-			paths <- path
+	return files
+}
+
+func (p *Parser) walkDir(ctx context.Context, dirname string, paths chan<- walkedFile) error {
+	return walker.Walk(dirname, func(path string, info os.DirEntry) error {
+		if p.Ignorer != nil && p.Ignorer.Match(path, info.IsDir()) {
+			log.Debug().Str("file", path).Str("reason", "ignored file").Msg("skipping")
 			return nil
-		})
-	}()
+		}
+		if p.isSkipFile(path) {
+			log.Debug().Str("file", path).Str("reason", "skipped file").Msg("skipping")
+			return nil
+		}
 
-	return paths
+		select {
+		case paths <- walkedFile{path: path, dir: info.IsDir()}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// isSkipFile denotes if the path is one of the SkipFiles
+func (p *Parser) isSkipFile(path string) bool {
+	if len(p.SkipFiles) == 0 {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, f := range p.SkipFiles {
+		if skip, err := filepath.Abs(f); err == nil && skip == abs {
+			return true
+		}
+	}
+	return false
 }
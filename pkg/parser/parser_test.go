@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"context"
+	"fmt"
 	"go/token"
 	"io/ioutil"
 	"os"
@@ -36,6 +38,25 @@ func (p *testPrinter) PrintSuccessExitMessage() bool {
 	return true
 }
 
+// cleanTestPrinter is a testPrinter that is also passed files without findings
+type cleanTestPrinter struct {
+	testPrinter
+}
+
+func (p *cleanTestPrinter) PrintsCleanFiles() bool {
+	return true
+}
+
+// errTestPrinter is a testPrinter that fails to print every file
+type errTestPrinter struct {
+	testPrinter
+}
+
+func (p *errTestPrinter) Print(r *result.FileResults) error {
+	p.testPrinter.Print(r)
+	return fmt.Errorf("unable to print %s", r.Filename)
+}
+
 func testParser() (parser *Parser, err error) {
 	r := rule.TestRule
 	cwd, err := os.Getwd()
@@ -51,13 +72,22 @@ func testParser() (parser *Parser, err error) {
 	return
 }
 
-func parsePathTests(t *testing.T) {
+func testParserWithJobs(jobs int) (*Parser, error) {
+	p, err := testParser()
+	if err != nil {
+		return nil, err
+	}
+	p.Jobs = jobs
+	return p, nil
+}
+
+func parsePathTests(t *testing.T, jobs int) {
 	t.Run("finding", func(t *testing.T) {
 		f, err := newFile(t, "i have a whitelist")
 		assert.NoError(t, err)
 
 		pr := new(testPrinter)
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		findings := p.ParsePaths(pr, f.Name())
 		assert.Len(t, pr.results, 1)
@@ -93,7 +123,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "i have a no findings\n")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		pr := new(testPrinter)
 		findings := p.ParsePaths(pr, f.Name())
@@ -101,11 +131,40 @@ func parsePathTests(t *testing.T) {
 		assert.Equal(t, len(pr.results), findings)
 	})
 
+	t.Run("no findings - clean files printed", func(t *testing.T) {
+		f1, err := newFile(t, "i have a whitelist\n")
+		assert.NoError(t, err)
+		f2, err := newFile(t, "i have a no findings\n")
+		assert.NoError(t, err)
+
+		p, err := testParserWithJobs(jobs)
+		assert.NoError(t, err)
+		pr := new(cleanTestPrinter)
+		findings := p.ParsePaths(pr, f1.Name(), f2.Name())
+		assert.Equal(t, 1, findings)
+		assert.Len(t, pr.results, 2)
+		for _, r := range pr.results {
+			if r.Filename == filepath.ToSlash(f2.Name()) {
+				assert.Empty(t, r.Results)
+			} else {
+				assert.Len(t, r.Results, 1)
+			}
+		}
+
+		// directories are only checked for findings in their names, so they are never clean files
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "clean.txt"), []byte("no findings\n"), 0o644))
+		pr = new(cleanTestPrinter)
+		assert.Equal(t, 0, p.ParsePaths(pr, dir))
+		assert.Len(t, pr.results, 1)
+		assert.Equal(t, filepath.ToSlash(filepath.Join(dir, "clean.txt")), pr.results[0].Filename)
+	})
+
 	t.Run("finding in filename - empty file", func(t *testing.T) {
 		f, err := newFileWithPrefix(t, "whitelist", "")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		pr := new(testPrinter)
 		findings := p.ParsePaths(pr, f.Name())
@@ -117,7 +176,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		pr := new(testPrinter)
 		findings := p.ParsePaths(pr, f.Name())
@@ -132,7 +191,7 @@ func parsePathTests(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Test with multiple paths supplied
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		pr := new(testPrinter)
 		findings := p.ParsePaths(pr, f1.Name(), f2.Name())
@@ -144,7 +203,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "i have a whitelist finding, but am ignored\n")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		cwd, err := os.Getwd()
 		assert.NoError(t, err)
@@ -163,7 +222,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "i have a whitelist finding, but am ignored # langcheckignore:rule=whitelist\n")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		pr := new(testPrinter)
 
@@ -176,7 +235,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "i have a whitelist finding, but am ignored # langcheckignore:rule=whitelist\n")
 		assert.NoError(t, err)
 
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		p.Ignorer = nil
 		pr := new(testPrinter)
@@ -188,7 +247,7 @@ func parsePathTests(t *testing.T) {
 
 	t.Run("default path", func(t *testing.T) {
 		// Test default path (which would run tests against the parser package)
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		cwd, err := os.Getwd()
 		assert.NoError(t, err)
@@ -205,7 +264,7 @@ func parsePathTests(t *testing.T) {
 
 	t.Run("stdin", func(t *testing.T) {
 		err := writeToStdin(t, "i have a whitelist here\n", func() {
-			p, err := testParser()
+			p, err := testParserWithJobs(jobs)
 			assert.NoError(t, err)
 			pr := new(testPrinter)
 			findings := p.ParsePaths(pr, os.Stdin.Name())
@@ -244,7 +303,7 @@ func parsePathTests(t *testing.T) {
 		f, err := newFile(t, "i have a whitelist")
 		assert.NoError(t, err)
 		const TestNote = "TEST NOTE"
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		p.Rules[0].Note = TestNote
 		p.Rules[0].Options.IncludeNote = nil
@@ -259,7 +318,7 @@ func parsePathTests(t *testing.T) {
 		assert.NoError(t, err)
 		const TestNote = "TEST NOTE"
 		includeNote := true
-		p, err := testParser()
+		p, err := testParserWithJobs(jobs)
 		assert.NoError(t, err)
 		p.Rules[0].Note = TestNote
 		p.Rules[0].Options.IncludeNote = &includeNote
@@ -273,14 +332,135 @@ func parsePathTests(t *testing.T) {
 }
 
 func TestParser_ParsePaths(t *testing.T) {
-	t.Cleanup(func() {
-		os.Unsetenv("WORKER_POOL_COUNT")
+	// default number of jobs
+	parsePathTests(t, 0)
+	// bounded number of jobs
+	parsePathTests(t, 1)
+	parsePathTests(t, 10)
+}
+
+func TestParser_ParsePathsContext(t *testing.T) {
+	t.Run("cancelled", func(t *testing.T) {
+		f, err := newFile(t, "i have a whitelist")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		pr := new(testPrinter)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		findings, err := p.ParsePathsContext(ctx, pr, f.Name())
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, findings)
+		assert.Len(t, pr.results, 0)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		var files []string
+		for i := 0; i < 10; i++ {
+			f, err := newFile(t, "i have a whitelist")
+			assert.NoError(t, err)
+			files = append(files, f.Name())
+		}
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		p.FailFast = true
+		pr := new(testPrinter)
+
+		findings, err := p.ParsePathsContext(context.Background(), pr, files...)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, findings)
+		assert.Len(t, pr.results, 1)
+	})
+
+	t.Run("print errors", func(t *testing.T) {
+		f1, err := newFile(t, "i have a whitelist")
+		assert.NoError(t, err)
+		f2, err := newFile(t, "i have a whitelist too")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		pr := new(errTestPrinter)
+
+		// every file is still printed, and all the errors are returned
+		findings, err := p.ParsePathsContext(context.Background(), pr, f1.Name(), f2.Name())
+		assert.EqualError(t, err, fmt.Sprintf("unable to print %s\nunable to print %s", pr.results[0].Filename, pr.results[1].Filename))
+		assert.NotErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 2, findings)
+		assert.Len(t, pr.results, 2)
+	})
+
+	t.Run("fail fast without findings", func(t *testing.T) {
+		f, err := newFile(t, "i have no findings")
+		assert.NoError(t, err)
+
+		p, err := testParser()
+		assert.NoError(t, err)
+		p.FailFast = true
+		pr := new(testPrinter)
+
+		findings, err := p.ParsePathsContext(context.Background(), pr, f.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, findings)
 	})
-	os.Unsetenv("WORKER_POOL_COUNT")
-	parsePathTests(t)
+}
+
+func TestParser_ParsePathsSorted(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"c.txt", "a.txt", "d/b.txt", "b.txt"}
+	for _, name := range names {
+		filename := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		assert.NoError(t, os.WriteFile(filename, []byte("i have a whitelist\nand another whitelist\n"), 0o600))
+	}
 
-	os.Setenv("WORKER_POOL_COUNT", "10")
-	parsePathTests(t)
+	for i := 0; i < 5; i++ {
+		p, err := testParserWithJobs(4)
+		assert.NoError(t, err)
+		pr := new(testPrinter)
+		findings := p.ParsePaths(pr, dir)
+		assert.Equal(t, len(names), findings)
+
+		var got []string
+		for _, r := range pr.results {
+			got = append(got, r.Filename)
+			assert.Equal(t, 1, r.Results[0].GetStartPosition().Line)
+			assert.Equal(t, 2, r.Results[1].GetStartPosition().Line)
+		}
+		assert.Equal(t, []string{
+			filepath.ToSlash(filepath.Join(dir, "a.txt")),
+			filepath.ToSlash(filepath.Join(dir, "b.txt")),
+			filepath.ToSlash(filepath.Join(dir, "c.txt")),
+			filepath.ToSlash(filepath.Join(dir, "d/b.txt")),
+		}, got)
+	}
+
+	p, err := testParserWithJobs(4)
+	assert.NoError(t, err)
+	p.Unsorted = true
+	pr := new(testPrinter)
+	assert.Equal(t, len(names), p.ParsePaths(pr, dir))
+	assert.Len(t, pr.results, len(names))
+}
+
+func TestParser_ParsePathsSkipFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "baseline.yaml"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("i have a whitelist\n"), 0o600))
+	}
+
+	// skipped files aren't checked, even without an ignorer
+	r := rule.TestRule
+	p := NewParser([]*rule.Rule{&r}, nil)
+	p.SkipFiles = []string{filepath.Join(dir, "baseline.yaml")}
+	pr := new(testPrinter)
+	assert.Equal(t, 1, p.ParsePaths(pr, dir))
+	if assert.Len(t, pr.results, 1) {
+		assert.Equal(t, filepath.ToSlash(filepath.Join(dir, "a.txt")), pr.results[0].Filename)
+	}
 }
 
 func writeToStdin(t *testing.T, text string, f func()) error {
@@ -0,0 +1,116 @@
+package printer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GitLab is a JSON printer for GitLab Code Quality reports, which are shown in merge requests
+type GitLab struct {
+	writer  io.Writer
+	newList bool
+	// occurrences counts the findings with the same fingerprint, so each fingerprint in the report is unique
+	occurrences map[string]int
+}
+
+type GitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    GitLabLocation `json:"location"`
+}
+
+type GitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines GitLabLines `json:"lines"`
+}
+
+type GitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// NewGitLab returns a new GitLab Code Quality printer
+func NewGitLab(w io.Writer) *GitLab {
+	return &GitLab{writer: w, newList: true, occurrences: map[string]int{}}
+}
+
+func (p *GitLab) PrintSuccessExitMessage() bool {
+	return false
+}
+
+func calculateGitLabSeverity(s rule.Severity) string {
+	// Translate the severity to GitLab Code Quality terms
+	if s == rule.SevWarn {
+		return "minor"
+	} else if s == rule.SevInfo {
+		return "info"
+	}
+	return "major"
+}
+
+// gitLabFingerprint returns a fingerprint of the finding that doesn't change when unrelated lines are added or
+// removed, so GitLab can tell which findings are new in a merge request
+func (p *GitLab) gitLabFingerprint(filename string, r result.Result) string {
+	fingerprint := filename + "\x00" + result.Fingerprint(r)
+	n := p.occurrences[fingerprint]
+	p.occurrences[fingerprint]++
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", fingerprint, n)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Print outputs lines in FileResults as GitLab Code Quality issues.
+// NOTE: Start() must be called before printing results and End()
+// after printing is complete in order to form a valid JSON array.
+func (p *GitLab) Print(fs *result.FileResults) error {
+	for _, res := range fs.Results {
+		issue := GitLabIssue{
+			Description: res.Reason(),
+			CheckName:   res.GetRuleName(),
+			Fingerprint: p.gitLabFingerprint(fs.Filename, res),
+			Severity:    calculateGitLabSeverity(res.GetSeverity()),
+			Location: GitLabLocation{
+				Path: fs.Filename,
+				Lines: GitLabLines{
+					Begin: res.GetStartPosition().Line,
+					End:   res.GetEndPosition().Line,
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(issue); err != nil {
+			log.Error().Err(err).Msg("Error encoding issue")
+			continue
+		}
+
+		if !p.newList {
+			fmt.Fprint(p.writer, `,`) // add comma between issues in list
+		} else {
+			p.newList = false
+		}
+
+		fmt.Fprint(p.writer, buf.String()) // json Encoder already puts a new line in, so no need for Println here
+	}
+
+	return nil
+}
+
+func (p *GitLab) Start() {
+	fmt.Fprint(p.writer, `[`)
+}
+
+func (p *GitLab) End() {
+	fmt.Fprint(p.writer, `]`+"\n")
+}
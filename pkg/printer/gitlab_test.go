@@ -0,0 +1,77 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateGitLabSeverity(t *testing.T) {
+	assert.Equal(t, "major", calculateGitLabSeverity(rule.SevError))
+	assert.Equal(t, "minor", calculateGitLabSeverity(rule.SevWarn))
+	assert.Equal(t, "info", calculateGitLabSeverity(rule.SevInfo))
+}
+
+func TestGitLab_Print(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewGitLab(buf)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	assert.NoError(t, p.Print(generateSecondFileResult()))
+	p.End()
+
+	var issues []GitLabIssue
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+	assert.Len(t, issues, 2)
+	assert.Equal(t, GitLabIssue{
+		Description: "`whitelist` may be insensitive, use `allowlist` instead",
+		CheckName:   "whitelist",
+		Fingerprint: issues[0].Fingerprint,
+		Severity:    "minor",
+		Location: GitLabLocation{
+			Path:  "foo.txt",
+			Lines: GitLabLines{Begin: 1, End: 1},
+		},
+	}, issues[0])
+	assert.Len(t, issues[0].Fingerprint, 64)
+	assert.Equal(t, "slave", issues[1].CheckName)
+	assert.Equal(t, "major", issues[1].Severity)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	assert.False(t, p.PrintSuccessExitMessage())
+}
+
+func TestGitLab_Fingerprint(t *testing.T) {
+	print := func() []GitLabIssue {
+		buf := new(bytes.Buffer)
+		p := NewGitLab(buf)
+		p.Start()
+		r := generateFileResult()
+		// the same finding twice on the same line
+		r.Results = append(r.Results, r.Results[0])
+		assert.NoError(t, p.Print(r))
+		p.End()
+
+		var issues []GitLabIssue
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+		return issues
+	}
+
+	issues := print()
+	assert.Len(t, issues, 2)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+
+	// fingerprints are the same on every run
+	assert.Equal(t, issues, print())
+}
+
+func TestGitLab_Empty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewGitLab(buf)
+	p.Start()
+	p.End()
+	assert.Equal(t, "[]\n", buf.String())
+}
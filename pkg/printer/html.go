@@ -0,0 +1,146 @@
+package printer
+
+import (
+	// empty import required as a part of the embed package
+	// https://golang.google.cn/pkg/embed/
+	_ "embed"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+)
+
+//go:embed html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("html").Parse(htmlTemplateText))
+
+// HTML is a printer that outputs a single, self-contained HTML report, meant to be opened in a browser
+type HTML struct {
+	writer    io.Writer
+	files     []HTMLFile
+	rules     []*HTMLRule
+	ruleIndex map[string]int
+}
+
+// HTMLReport is the data that the HTML report is rendered from
+type HTMLReport struct {
+	Total      int
+	Severities []HTMLSeverity
+	Rules      []*HTMLRule
+	Files      []HTMLFile
+}
+
+type HTMLSeverity struct {
+	Name  string
+	Count int
+}
+
+type HTMLRule struct {
+	Name         string
+	Severity     string
+	Alternatives []string
+	Note         string
+	Count        int
+}
+
+type HTMLFile struct {
+	Filename string
+	Findings []HTMLFinding
+}
+
+type HTMLFinding struct {
+	Rule     string
+	Severity string
+	Position string
+	Reason   string
+	// Before, Finding and After are the line split around the finding, so the finding can be highlighted.
+	// If the line isn't available, they are all empty.
+	Before  string
+	Finding string
+	After   string
+}
+
+// NewHTML returns a new HTML printer
+func NewHTML(w io.Writer) *HTML {
+	return &HTML{writer: w, ruleIndex: map[string]int{}}
+}
+
+func (p *HTML) PrintSuccessExitMessage() bool {
+	return false
+}
+
+// Print collects the results in FileResults for the report.
+// NOTE: Nothing is written until End() is called, since the summary is written before the files.
+func (p *HTML) Print(fs *result.FileResults) error {
+	file := HTMLFile{Filename: fs.Filename}
+	for _, r := range fs.Results {
+		p.addRule(r.GetRule()).Count++
+
+		f := HTMLFinding{
+			Rule:     r.GetRuleName(),
+			Severity: r.GetSeverity().String(),
+			Position: positionString(r.GetStartPosition()),
+			Reason:   r.Reason(),
+		}
+		f.Before, f.Finding, f.After = splitLine(r)
+		file.Findings = append(file.Findings, f)
+	}
+	p.files = append(p.files, file)
+	return nil
+}
+
+// addRule adds the rule to the report's rules, if it hasn't been added yet, and returns it
+func (p *HTML) addRule(r *rule.Rule) *HTMLRule {
+	if i, ok := p.ruleIndex[r.Name]; ok {
+		return p.rules[i]
+	}
+
+	hr := &HTMLRule{
+		Name:         r.Name,
+		Severity:     r.Severity.String(),
+		Alternatives: r.Alternatives,
+		Note:         r.Note,
+	}
+	p.rules = append(p.rules, hr)
+	p.ruleIndex[r.Name] = len(p.rules) - 1
+	return hr
+}
+
+// splitLine splits the line of the result into the text before the finding, the finding, and the text after it.
+// If the columns of the finding aren't within the line, the whole line is returned as the text before the finding.
+func splitLine(r result.Result) (before, finding, after string) {
+	line := r.GetLine()
+	start, end := r.GetStartPosition().Column, r.GetEndPosition().Column
+	if start < 0 || start >= end || end > len(line) {
+		return line, "", ""
+	}
+	return line[:start], line[start:end], line[end:]
+}
+
+func (p *HTML) Start() {
+}
+
+func (p *HTML) End() {
+	report := HTMLReport{Rules: append([]*HTMLRule{}, p.rules...), Files: p.files}
+
+	counts := map[rule.Severity]int{}
+	for _, r := range p.rules {
+		counts[rule.NewSeverity(r.Severity)] += r.Count
+		report.Total += r.Count
+	}
+	for _, s := range []rule.Severity{rule.SevError, rule.SevWarn, rule.SevInfo} {
+		report.Severities = append(report.Severities, HTMLSeverity{Name: s.String(), Count: counts[s]})
+	}
+
+	// the rules with the most findings first
+	sort.SliceStable(report.Rules, func(i, j int) bool {
+		return report.Rules[i].Count > report.Rules[j].Count
+	})
+
+	if err := htmlTemplate.Execute(p.writer, report); err != nil {
+		panic(err)
+	}
+}
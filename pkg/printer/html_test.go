@@ -0,0 +1,72 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML_Print(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewHTML(buf)
+	p.Start()
+	assert.NoError(t, p.Print(&result.FileResults{
+		Filename: "foo.html",
+		Results:  result.FindResults(&rule.TestRule, "foo.html", "<b>this whitelist must change</b>", 3),
+	}))
+	assert.NoError(t, p.Print(generateSecondFileResult()))
+	p.End()
+
+	out := buf.String()
+	assert.Contains(t, out, "<!DOCTYPE html>")
+	assert.Contains(t, out, "<p>2 findings in 2 files.</p>")
+	assert.Contains(t, out, `<tr><td><span class="severity error">error</span></td><td>1</td></tr>`)
+	assert.Contains(t, out, `<tr><td><span class="severity warning">warning</span></td><td>1</td></tr>`)
+	assert.Contains(t, out, `<tr><td><span class="severity info">info</span></td><td>0</td></tr>`)
+	assert.Contains(t, out, `<tr><td>whitelist</td><td><span class="severity warning">warning</span></td><td>1</td><td>allowlist</td><td></td></tr>`)
+	assert.Contains(t, out, "<summary>foo.html (1)</summary>")
+	assert.Contains(t, out, `<span class="position">foo.html:3:8</span>`)
+	// the line is escaped, and the finding is highlighted
+	assert.Contains(t, out, "<pre>&lt;b&gt;this <mark>whitelist</mark> must change&lt;/b&gt;</pre>")
+	assert.Contains(t, out, "<summary>bar.txt (1)</summary>")
+	assert.NotContains(t, out, "No findings.")
+	assert.False(t, p.PrintSuccessExitMessage())
+}
+
+func TestHTML_Empty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewHTML(buf)
+	p.Start()
+	p.End()
+
+	assert.Contains(t, buf.String(), "<p>No findings.</p>")
+	assert.NotContains(t, buf.String(), "<details>")
+}
+
+func TestSplitLine(t *testing.T) {
+	rs := result.FindResults(&rule.TestRule, "my/file", "this whitelist must change", 1)
+	before, finding, after := splitLine(rs[0])
+	assert.Equal(t, "this ", before)
+	assert.Equal(t, "whitelist", finding)
+	assert.Equal(t, " must change", after)
+
+	// columns outside of the line aren't highlighted
+	r := result.LineResult{
+		Rule:          &rule.TestRule,
+		Line:          "short",
+		StartPosition: newPosition("my/file", 1, 6),
+		EndPosition:   newPosition("my/file", 1, 15),
+	}
+	before, finding, after = splitLine(r)
+	assert.Equal(t, "short", before)
+	assert.Empty(t, finding)
+	assert.Empty(t, after)
+
+	// path results have no line
+	before, finding, after = splitLine(result.MatchPath(&rule.TestRule, "my/whitelist")[0])
+	assert.Empty(t, before+finding+after)
+}
@@ -0,0 +1,126 @@
+package printer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+)
+
+// JUnit is a JUnit XML printer meant for CI test report dashboards, such as Jenkins and GitLab.
+// Each file is a test case, which fails if the file has findings.
+type JUnit struct {
+	writer io.Writer
+	// IncludePassing also prints a passing test case for each file without findings
+	IncludePassing bool
+	cases          []JUnitTestCase
+	failures       int
+}
+
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnit returns a new JUnit printer
+func NewJUnit(w io.Writer, includePassing bool) *JUnit {
+	return &JUnit{
+		writer:         w,
+		IncludePassing: includePassing,
+	}
+}
+
+func (p *JUnit) PrintSuccessExitMessage() bool {
+	return false
+}
+
+// PrintsCleanFiles denotes if files without findings should be printed, as passing test cases
+func (p *JUnit) PrintsCleanFiles() bool {
+	return p.IncludePassing
+}
+
+// Print collects the results in FileResults as a test case, which fails with all of the file's findings.
+// NOTE: Nothing is written until End() is called, since the totals are written before the test cases.
+func (p *JUnit) Print(fs *result.FileResults) error {
+	tc := JUnitTestCase{
+		Name:      fs.Filename,
+		ClassName: "language-checker",
+	}
+	if len(fs.Results) == 0 {
+		p.cases = append(p.cases, tc)
+		return nil
+	}
+
+	// the type of the failure is the most severe severity of the findings
+	severity := rule.SevInfo
+	lines := make([]string, len(fs.Results))
+	for i, r := range fs.Results {
+		if r.GetSeverity() < severity {
+			severity = r.GetSeverity()
+		}
+		lines[i] = fmt.Sprintf("%s: [%s] %s", positionString(r.GetStartPosition()), r.GetSeverity(), r.Reason())
+	}
+
+	message := fmt.Sprintf("%d findings", len(fs.Results))
+	if len(fs.Results) == 1 {
+		message = fs.Results[0].Reason()
+	}
+	tc.Failure = &JUnitFailure{
+		Message: message,
+		Type:    severity.String(),
+		Text:    strings.Join(lines, "\n"),
+	}
+	p.cases = append(p.cases, tc)
+	p.failures++
+	return nil
+}
+
+func (p *JUnit) Start() {
+}
+
+func (p *JUnit) End() {
+	suites := JUnitTestSuites{
+		Name:     "language-checker",
+		Tests:    len(p.cases),
+		Failures: p.failures,
+		Suites: []JUnitTestSuite{{
+			Name:     "language-checker",
+			Tests:    len(p.cases),
+			Failures: p.failures,
+			Cases:    p.cases,
+		}},
+	}
+
+	fmt.Fprint(p.writer, xml.Header)
+	encoder := xml.NewEncoder(p.writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		panic(err)
+	}
+	fmt.Fprintln(p.writer)
+}
@@ -0,0 +1,52 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJUnit_Print(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewJUnit(buf, true)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	fr := generateSecondFileResult()
+	fr.Results = append(fr.Results, generateThirdResults(fr.Filename)...)
+	assert.NoError(t, p.Print(fr))
+	assert.NoError(t, p.Print(&result.FileResults{Filename: "clean.txt"}))
+	p.End()
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="language-checker" tests="3" failures="2">
+  <testsuite name="language-checker" tests="3" failures="2">
+    <testcase name="foo.txt" classname="language-checker">
+      <failure message="` + "`whitelist` may be insensitive, use `allowlist` instead" + `" type="warning">foo.txt:1:6: [warning] ` + "`whitelist` may be insensitive, use `allowlist` instead" + `</failure>
+    </testcase>
+    <testcase name="bar.txt" classname="language-checker">
+      <failure message="2 findings" type="error">bar.txt:1:6: [error] ` + "`slave` may be insensitive, use `follower` instead" + `&#xA;bar.txt:1:6: [info] ` + "`test` may be insensitive, use `alternative` instead" + `</failure>
+    </testcase>
+    <testcase name="clean.txt" classname="language-checker"></testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buf.String())
+	assert.False(t, p.PrintSuccessExitMessage())
+}
+
+func TestJUnit_Empty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewJUnit(buf, false)
+	p.Start()
+	p.End()
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="language-checker" tests="0" failures="0">
+  <testsuite name="language-checker" tests="0" failures="0"></testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buf.String())
+}
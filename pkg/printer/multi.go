@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"errors"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+)
+
+// Multi is a printer that prints the results with several printers, so a single run can produce several outputs
+type Multi struct {
+	printers []Printer
+}
+
+// NewMulti returns a printer that prints the results with all of the printers
+func NewMulti(printers ...Printer) *Multi {
+	return &Multi{printers: printers}
+}
+
+// PrintSuccessExitMessage denotes if any of the printers print a success exit message
+func (p *Multi) PrintSuccessExitMessage() bool {
+	for _, pr := range p.printers {
+		if pr.PrintSuccessExitMessage() {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintsCleanFiles denotes if any of the printers print files without findings
+func (p *Multi) PrintsCleanFiles() bool {
+	for _, pr := range p.printers {
+		if PrintsCleanFiles(pr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Print prints the FileResults with each printer. Files without findings
+// are only printed by the printers that print clean files.
+func (p *Multi) Print(fs *result.FileResults) error {
+	var errs []error
+	for _, pr := range p.printers {
+		if len(fs.Results) == 0 && !PrintsCleanFiles(pr) {
+			continue
+		}
+		if err := pr.Print(fs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Multi) Start() {
+	for _, pr := range p.printers {
+		pr.Start()
+	}
+}
+
+func (p *Multi) End() {
+	for _, pr := range p.printers {
+		pr.End()
+	}
+}
+
+// Err returns the errors of the printers that failed in End
+func (p *Multi) Err() error {
+	var errs []error
+	for _, pr := range p.printers {
+		if err := Err(pr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,34 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMulti_Print(t *testing.T) {
+	simple, junit := new(bytes.Buffer), new(bytes.Buffer)
+	p := NewMulti(NewSimple(simple), NewJUnit(junit, true))
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	assert.NoError(t, p.Print(&result.FileResults{Filename: "clean.txt"}))
+	p.End()
+
+	// clean files are only printed by the printers that print them
+	assert.Equal(t, "foo.txt:1:6: [warning] `whitelist` may be insensitive, use `allowlist` instead\n", simple.String())
+	assert.Contains(t, junit.String(), `<testcase name="foo.txt" classname="language-checker">`)
+	assert.Contains(t, junit.String(), `<testcase name="clean.txt" classname="language-checker"></testcase>`)
+
+	assert.True(t, p.PrintsCleanFiles())
+	assert.True(t, p.PrintSuccessExitMessage())
+}
+
+func TestMulti_Options(t *testing.T) {
+	p := NewMulti(NewSARIF(new(bytes.Buffer)), NewJUnit(new(bytes.Buffer), false))
+	assert.False(t, p.PrintsCleanFiles())
+	assert.False(t, PrintsCleanFiles(p))
+	assert.False(t, p.PrintSuccessExitMessage())
+}
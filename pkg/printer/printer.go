@@ -19,6 +19,44 @@ type Printer interface {
 	PrintSuccessExitMessage() bool
 }
 
+// CleanFilePrinter is a Printer that may also print files without findings.
+// If PrintsCleanFiles is true, files without findings are passed to Print with no results.
+type CleanFilePrinter interface {
+	Printer
+	PrintsCleanFiles() bool
+}
+
+// PrintsCleanFiles denotes if the printer should be passed files without findings
+func PrintsCleanFiles(p Printer) bool {
+	cp, ok := p.(CleanFilePrinter)
+	return ok && cp.PrintsCleanFiles()
+}
+
+// ErrorPrinter is a Printer that may fail to print the results in End, which can't return an error
+type ErrorPrinter interface {
+	Printer
+	// Err returns the error of End, if it failed
+	Err() error
+}
+
+// Err returns the error of the printer's End, if the printer can fail in End
+func Err(p Printer) error {
+	if ep, ok := p.(ErrorPrinter); ok {
+		return ep.Err()
+	}
+	return nil
+}
+
+// Options are options for the printers that support them
+type Options struct {
+	// IncludePassing includes files without findings, as passing test cases in junit output
+	IncludePassing bool
+	// Template is the path of the text/template file for template output
+	Template string
+	// DisableColor disables colors in text output, such as when it is written to a file
+	DisableColor bool
+}
+
 const (
 	// OutFormatText is a text-based output format, best for CLIs
 	OutFormatText = "text"
@@ -38,6 +76,25 @@ const (
 	// OutFormatCheckstyle outputs in checkstyle format.
 	// https://github.com/checkstyle/checkstyle
 	OutFormatCheckstyle = "checkstyle"
+
+	// OutFormatSARIF outputs in SARIF 2.1.0 format
+	// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+	OutFormatSARIF = "sarif"
+
+	// OutFormatJUnit outputs in JUnit XML format, which is supported by many CI test report dashboards
+	// https://github.com/testmoapp/junitxml
+	OutFormatJUnit = "junit"
+
+	// OutFormatGitLab outputs a GitLab Code Quality report
+	// https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+	OutFormatGitLab = "gitlab"
+
+	// OutFormatHTML outputs a self-contained HTML report, which can be opened in a browser
+	OutFormatHTML = "html"
+
+	// OutFormatTemplate renders the results with a text/template file
+	// https://pkg.go.dev/text/template
+	OutFormatTemplate = "template"
 )
 
 // OutFormats are all the available output formats. The first one should be the default
@@ -48,6 +105,11 @@ var OutFormats = []string{
 	OutFormatJSON,
 	OutFormatSonarQube,
 	OutFormatCheckstyle,
+	OutFormatSARIF,
+	OutFormatJUnit,
+	OutFormatGitLab,
+	OutFormatHTML,
+	OutFormatTemplate,
 }
 
 // OutFormatsString is all OutFormats, as a comma-separated string
@@ -55,10 +117,16 @@ var OutFormatsString = strings.Join(OutFormats, ",")
 
 // NewPrinter returns a valid new Printer from a string, or an error if the printer is invalid
 func NewPrinter(f string, w io.Writer) (Printer, error) {
+	return NewPrinterWithOptions(f, w, Options{})
+}
+
+// NewPrinterWithOptions returns a valid new Printer from a string, configured with the options
+// that apply to it, or an error if the printer is invalid
+func NewPrinterWithOptions(f string, w io.Writer, o Options) (Printer, error) {
 	var p Printer
 	switch f {
 	case OutFormatText:
-		p = NewText(w, env.GetBoolDefault("DISABLE_COLORS", false))
+		p = NewText(w, o.DisableColor || env.GetBoolDefault("DISABLE_COLORS", false))
 	case OutFormatSimple:
 		p = NewSimple(w)
 	case OutFormatGitHubActions:
@@ -69,6 +137,20 @@ func NewPrinter(f string, w io.Writer) (Printer, error) {
 		p = NewSonarQube(w)
 	case OutFormatCheckstyle:
 		p = NewCheckstyle(w)
+	case OutFormatSARIF:
+		p = NewSARIF(w)
+	case OutFormatJUnit:
+		p = NewJUnit(w, o.IncludePassing)
+	case OutFormatGitLab:
+		p = NewGitLab(w)
+	case OutFormatHTML:
+		p = NewHTML(w)
+	case OutFormatTemplate:
+		t, err := NewTemplate(w, o.Template)
+		if err != nil {
+			return nil, err
+		}
+		p = t
 	default:
 		return p, fmt.Errorf("%s is not a valid printer type", f)
 	}
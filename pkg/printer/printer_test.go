@@ -18,6 +18,10 @@ func TestCreatePrinter(t *testing.T) {
 		{OutFormatJSON, &JSON{}},
 		{OutFormatSonarQube, &SonarQube{}},
 		{OutFormatCheckstyle, &Checkstyle{}},
+		{OutFormatSARIF, &SARIF{}},
+		{OutFormatJUnit, &JUnit{}},
+		{OutFormatGitLab, &GitLab{}},
+		{OutFormatHTML, &HTML{}},
 	}
 
 	for _, test := range tests {
@@ -29,3 +33,17 @@ func TestCreatePrinter(t *testing.T) {
 	_, err := NewPrinter("invalid-printer", io.Discard)
 	assert.Errorf(t, err, "invalid-printer is not a valid printer type")
 }
+
+func TestPrintsCleanFiles(t *testing.T) {
+	p, err := NewPrinterWithOptions(OutFormatJUnit, io.Discard, Options{IncludePassing: true})
+	assert.NoError(t, err)
+	assert.True(t, PrintsCleanFiles(p))
+
+	p, err = NewPrinterWithOptions(OutFormatJUnit, io.Discard, Options{})
+	assert.NoError(t, err)
+	assert.False(t, PrintsCleanFiles(p))
+
+	p, err = NewPrinterWithOptions(OutFormatText, io.Discard, Options{IncludePassing: true})
+	assert.NoError(t, err)
+	assert.False(t, PrintsCleanFiles(p))
+}
@@ -0,0 +1,235 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI = "https://github.com/jdstrand/language-checker"
+)
+
+// SARIF is a printer that outputs a single SARIF 2.1.0 log, meant for
+// code scanning tools that ingest static analysis results
+type SARIF struct {
+	writer    io.Writer
+	rules     []SARIFRule
+	ruleIndex map[string]int
+	results   []SARIFResult
+}
+
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID                   string               `json:"id"`
+	Name                 string               `json:"name"`
+	ShortDescription     SARIFMessage         `json:"shortDescription"`
+	FullDescription      *SARIFMessage        `json:"fullDescription,omitempty"`
+	Help                 SARIFMessage         `json:"help"`
+	DefaultConfiguration SARIFConfiguration   `json:"defaultConfiguration"`
+	Properties           *SARIFRuleProperties `json:"properties,omitempty"`
+}
+
+type SARIFRuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           SARIFRegion           `json:"region"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// NewSARIF returns a new SARIF printer
+func NewSARIF(w io.Writer) *SARIF {
+	return &SARIF{
+		writer:    w,
+		ruleIndex: map[string]int{},
+	}
+}
+
+func (p *SARIF) PrintSuccessExitMessage() bool {
+	return false
+}
+
+func calculateSARIFLevel(s rule.Severity) string {
+	// Translate the severity to SARIF levels
+	if s == rule.SevWarn {
+		return "warning"
+	} else if s == rule.SevInfo {
+		return "note"
+	}
+	return "error"
+}
+
+// Print collects the results in FileResults to be included in the SARIF log.
+// NOTE: Nothing is written until End() is called, since SARIF is a single JSON document.
+func (p *SARIF) Print(fs *result.FileResults) error {
+	for _, res := range fs.Results {
+		region := SARIFRegion{
+			StartLine: res.GetStartPosition().Line,
+			// SARIF columns are 1-based, and the end column is the
+			// column of the character following the finding
+			StartColumn: sarifColumn(res.GetLine(), res.GetStartPosition().Column),
+			EndLine:     res.GetEndPosition().Line,
+			EndColumn:   sarifColumn(res.GetLine(), res.GetEndPosition().Column),
+		}
+
+		// start column and end column are both 1 for file results, all other findings
+		// should be at least 1 character long
+		if res.GetStartPosition().Column == 1 && res.GetEndPosition().Column == 1 {
+			region = SARIFRegion{StartLine: res.GetStartPosition().Line}
+		}
+
+		p.results = append(p.results, SARIFResult{
+			RuleID:    res.GetRuleName(),
+			RuleIndex: p.addRule(res.GetRule()),
+			Level:     calculateSARIFLevel(res.GetSeverity()),
+			Message:   SARIFMessage{Text: res.Reason()},
+			Locations: []SARIFLocation{{
+				PhysicalLocation: SARIFPhysicalLocation{
+					ArtifactLocation: SARIFArtifactLocation{URI: fs.Filename},
+					Region:           region,
+				},
+			}},
+		})
+	}
+
+	return nil
+}
+
+// sarifColumn converts the byte offset column in the line to a 1-based SARIF column,
+// which counts UTF-16 code units by default. Lines that are too long to be kept
+// in the result are counted in bytes.
+func sarifColumn(line string, column int) int {
+	if column > len(line) {
+		return column + 1
+	}
+	n := 1
+	for _, r := range line[:column] {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// addRule adds the rule to the driver's rules, if it hasn't been added yet,
+// and returns the index of the rule
+func (p *SARIF) addRule(r *rule.Rule) int {
+	if i, ok := p.ruleIndex[r.Name]; ok {
+		return i
+	}
+
+	sr := SARIFRule{
+		ID:                   r.Name,
+		Name:                 r.Name,
+		ShortDescription:     SARIFMessage{Text: r.Reason("")},
+		Help:                 SARIFMessage{Text: sarifHelpText(r)},
+		DefaultConfiguration: SARIFConfiguration{Level: calculateSARIFLevel(r.Severity)},
+	}
+	if len(r.Note) > 0 {
+		sr.FullDescription = &SARIFMessage{Text: r.Note}
+	}
+	if len(r.Options.Categories) > 0 {
+		sr.Properties = &SARIFRuleProperties{Tags: r.Options.Categories}
+	}
+
+	p.rules = append(p.rules, sr)
+	p.ruleIndex[r.Name] = len(p.rules) - 1
+	return len(p.rules) - 1
+}
+
+func sarifHelpText(r *rule.Rule) string {
+	if len(r.Alternatives) == 0 {
+		return "No alternatives available, try not to use it"
+	}
+	return fmt.Sprintf("Alternatives: %s", strings.Join(r.Alternatives, ", "))
+}
+
+func (p *SARIF) Start() {
+}
+
+func (p *SARIF) End() {
+	sarifLog := SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{
+				Driver: SARIFDriver{
+					Name:           "language-checker",
+					InformationURI: sarifToolURI,
+					Rules:          p.rules,
+				},
+			},
+			Results: p.results,
+		}},
+	}
+
+	// SARIF requires an array for rules and results, even when empty
+	if sarifLog.Runs[0].Tool.Driver.Rules == nil {
+		sarifLog.Runs[0].Tool.Driver.Rules = []SARIFRule{}
+	}
+	if sarifLog.Runs[0].Results == nil {
+		sarifLog.Runs[0].Results = []SARIFResult{}
+	}
+
+	if err := json.NewEncoder(p.writer).Encode(sarifLog); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,134 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateSARIFLevel(t *testing.T) {
+	assert.Equal(t, "error", calculateSARIFLevel(rule.SevError))
+	assert.Equal(t, "warning", calculateSARIFLevel(rule.SevWarn))
+	assert.Equal(t, "note", calculateSARIFLevel(rule.SevInfo))
+}
+
+func TestSARIF_PrintSuccessExitMessage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	assert.Equal(t, false, p.PrintSuccessExitMessage())
+}
+
+func TestSARIF_Print(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	// Nothing is written until End
+	assert.Equal(t, "", buf.String())
+	p.End()
+
+	expected := `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"language-checker","informationUri":"https://github.com/jdstrand/language-checker","rules":[{"id":"whitelist","name":"whitelist","shortDescription":{"text":"` + "`" + `whitelist` + "`" + ` may be insensitive, use ` + "`" + `allowlist` + "`" + ` instead"},"help":{"text":"Alternatives: allowlist"},"defaultConfiguration":{"level":"warning"}}]}},"results":[{"ruleId":"whitelist","ruleIndex":0,"level":"warning","message":{"text":"` + "`" + `whitelist` + "`" + ` may be insensitive, use ` + "`" + `allowlist` + "`" + ` instead"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"foo.txt"},"region":{"startLine":1,"startColumn":7,"endLine":1,"endColumn":16}}}]}]}]}` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestSARIF_PrintPath(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	p.Start()
+	assert.NoError(t, p.Print(generateFilePathResult()))
+	p.End()
+
+	var got SARIFLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Len(t, got.Runs[0].Results, 1)
+	assert.Equal(t, SARIFRegion{StartLine: 1}, got.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+}
+
+func TestSARIF_PrintUnicode(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	p.Start()
+	fs := &result.FileResults{Filename: "foo.txt"}
+	fs.Results = result.FindResults(&rule.TestRule, fs.Filename, "the 🎉 whitelist", 1) // langcheckignore:rule=whitelist
+	assert.NoError(t, p.Print(fs))
+	p.End()
+
+	var got SARIFLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Len(t, got.Runs[0].Results, 1)
+	// the emoji is 4 bytes, but 2 UTF-16 code units
+	assert.Equal(t, SARIFRegion{StartLine: 1, StartColumn: 8, EndLine: 1, EndColumn: 17}, got.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+}
+
+func TestSARIFColumn(t *testing.T) {
+	assert.Equal(t, 1, sarifColumn("whitelist", 0)) // langcheckignore:rule=whitelist
+	assert.Equal(t, 3, sarifColumn("é a", 3))
+	// lines that aren't kept are counted in bytes
+	assert.Equal(t, 11, sarifColumn("", 10))
+}
+
+func TestSARIF_Multiple(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	assert.NoError(t, p.Print(generateSecondFileResult()))
+	assert.NoError(t, p.Print(generateThirdFileResult()))
+	// a rule that was already seen should not be added twice
+	assert.NoError(t, p.Print(generateFileResult()))
+	p.End()
+
+	var got SARIFLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Len(t, got.Runs, 1)
+
+	rules := got.Runs[0].Tool.Driver.Rules
+	assert.Len(t, rules, 3)
+	assert.Equal(t, "whitelist", rules[0].ID)
+	assert.Equal(t, "slave", rules[1].ID)
+	assert.Equal(t, "Alternatives: follower", rules[1].Help.Text)
+	assert.Equal(t, "error", rules[1].DefaultConfiguration.Level)
+	assert.Equal(t, "test", rules[2].ID)
+
+	results := got.Runs[0].Results
+	assert.Len(t, results, 4)
+	assert.Equal(t, 0, results[0].RuleIndex)
+	assert.Equal(t, 1, results[1].RuleIndex)
+	assert.Equal(t, "error", results[1].Level)
+	assert.Equal(t, "bar.txt", results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 2, results[2].RuleIndex)
+	assert.Equal(t, "note", results[2].Level)
+	assert.Equal(t, 0, results[3].RuleIndex)
+}
+
+func TestSARIF_Note(t *testing.T) {
+	r := rule.TestRule
+	r.Note = "a note"
+	r.Alternatives = nil
+	r.Options.Categories = []string{"cat1"}
+
+	p := NewSARIF(new(bytes.Buffer))
+	assert.Equal(t, 0, p.addRule(&r))
+	assert.Equal(t, &SARIFMessage{Text: "a note"}, p.rules[0].FullDescription)
+	assert.Equal(t, "No alternatives available, try not to use it", p.rules[0].Help.Text)
+	assert.Equal(t, []string{"cat1"}, p.rules[0].Properties.Tags)
+}
+
+func TestSARIF_Empty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewSARIF(buf)
+	p.Start()
+	p.End()
+
+	var got SARIFLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.NotNil(t, got.Runs[0].Results)
+	assert.NotNil(t, got.Runs[0].Tool.Driver.Rules)
+	assert.Contains(t, buf.String(), `"rules":[]`)
+	assert.Contains(t, buf.String(), `"results":[]`)
+}
@@ -0,0 +1,135 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jdstrand/language-checker/pkg/result"
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNoTemplate is returned when the template output format is used without a template file
+var ErrNoTemplate = errors.New("a template file is required for template output, use `--template`")
+
+// templateFuncs are the functions available in templates, in addition to the text/template builtins
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// Template is a printer that renders the results with a user-provided text/template,
+// for report formats that aren't built in
+type Template struct {
+	writer    io.Writer
+	template  *template.Template
+	files     []*result.FileResults
+	rules     []*TemplateRule
+	ruleIndex map[string]int
+	// err is the error of executing the template in End
+	err error
+}
+
+// TemplateReport is the data that the template is executed with
+type TemplateReport struct {
+	// Files are the files with findings
+	Files   []*result.FileResults
+	Summary TemplateSummary
+}
+
+// TemplateSummary is a summary of the run
+type TemplateSummary struct {
+	Files    int
+	Findings int
+	Errors   int
+	Warnings int
+	Infos    int
+	// Rules are the rules with findings, in the order they were first found
+	Rules []*TemplateRule
+}
+
+// TemplateRule is a rule with findings, and the number of findings it has
+type TemplateRule struct {
+	*rule.Rule
+	Findings int
+}
+
+// NewTemplate returns a new Template printer for the template file at path
+func NewTemplate(w io.Writer, path string) (*Template, error) {
+	if path == "" {
+		return nil, ErrNoTemplate
+	}
+	t, err := template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{writer: w, template: t, ruleIndex: map[string]int{}}, nil
+}
+
+func (p *Template) PrintSuccessExitMessage() bool {
+	return false
+}
+
+// Print collects the results in FileResults for the template.
+// NOTE: Nothing is written until End() is called, since the template has access to the summary of the whole run.
+func (p *Template) Print(fs *result.FileResults) error {
+	for _, r := range fs.Results {
+		p.addRule(r.GetRule()).Findings++
+	}
+	p.files = append(p.files, fs)
+	return nil
+}
+
+// addRule adds the rule to the summary's rules, if it hasn't been added yet, and returns it
+func (p *Template) addRule(r *rule.Rule) *TemplateRule {
+	if i, ok := p.ruleIndex[r.Name]; ok {
+		return p.rules[i]
+	}
+
+	p.rules = append(p.rules, &TemplateRule{Rule: r})
+	p.ruleIndex[r.Name] = len(p.rules) - 1
+	return p.rules[len(p.rules)-1]
+}
+
+func (p *Template) Start() {
+}
+
+func (p *Template) End() {
+	report := TemplateReport{
+		Files:   p.files,
+		Summary: TemplateSummary{Files: len(p.files), Rules: p.rules},
+	}
+	for _, fs := range p.files {
+		for _, r := range fs.Results {
+			report.Summary.Findings++
+			switch r.GetSeverity() {
+			case rule.SevError:
+				report.Summary.Errors++
+			case rule.SevWarn:
+				report.Summary.Warnings++
+			default:
+				report.Summary.Infos++
+			}
+		}
+	}
+
+	// render the whole template before writing, so a template error doesn't leave partial output
+	var buf bytes.Buffer
+	if err := p.template.Execute(&buf, report); err != nil {
+		p.err = fmt.Errorf("unable to execute template: %w", err)
+		return
+	}
+	if _, err := buf.WriteTo(p.writer); err != nil {
+		log.Error().Err(err).Msg("Error writing template output")
+	}
+}
+
+// Err returns the error of executing the template, if End failed
+func (p *Template) Err() error {
+	return p.err
+}
@@ -0,0 +1,83 @@
+package printer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTemplate(t *testing.T, text string) string {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte(text), 0o600))
+	return path
+}
+
+func TestTemplate_Print(t *testing.T) {
+	path := writeTemplate(t, `{{range .Files}}{{.Filename}}
+{{range .Results}}  {{.GetStartPosition.Line}}:{{.GetStartPosition.Column}} {{.GetRuleName}} [{{.GetSeverity}}] {{.Reason}} ({{join .GetRule.Alternatives ", "}})
+{{end}}{{end}}{{with .Summary}}{{.Findings}} findings in {{.Files}} files: {{.Errors}} errors, {{.Warnings}} warnings, {{.Infos}} infos
+{{range .Rules}}{{.Name}} {{join .Terms "|"}} {{.Findings}}
+{{end}}{{end}}`)
+
+	buf := new(bytes.Buffer)
+	p, err := NewTemplate(buf, path)
+	assert.NoError(t, err)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	fr := generateSecondFileResult()
+	fr.Results = append(fr.Results, generateThirdResults(fr.Filename)...)
+	assert.NoError(t, p.Print(fr))
+	p.End()
+
+	expected := "foo.txt\n" +
+		"  1:6 whitelist [warning] `whitelist` may be insensitive, use `allowlist` instead (allowlist)\n" +
+		"bar.txt\n" +
+		"  1:6 slave [error] `slave` may be insensitive, use `follower` instead (follower)\n" +
+		"  1:6 test [info] `test` may be insensitive, use `alternative` instead (alternative)\n" +
+		"3 findings in 2 files: 1 errors, 1 warnings, 1 infos\n" +
+		"whitelist whitelist|white-list|whitelisted|white-listed 1\n" +
+		"slave slave 1\n" +
+		"test test 1\n"
+	assert.Equal(t, expected, buf.String())
+	assert.NoError(t, p.Err())
+	assert.False(t, p.PrintSuccessExitMessage())
+}
+
+func TestTemplate_ExecuteError(t *testing.T) {
+	path := writeTemplate(t, `{{range .Files}}{{.Filename}} {{.Missing}}{{end}}`)
+
+	buf := new(bytes.Buffer)
+	p, err := NewTemplate(buf, path)
+	assert.NoError(t, err)
+	p.Start()
+	assert.NoError(t, p.Print(generateFileResult()))
+	p.End()
+
+	// nothing is written when the template fails
+	assert.Empty(t, buf.String())
+	assert.ErrorContains(t, p.Err(), "unable to execute template")
+	assert.ErrorContains(t, Err(p), "can't evaluate field Missing")
+	assert.ErrorContains(t, NewMulti(NewSimple(buf), p).Err(), "unable to execute template")
+}
+
+func TestNewTemplate(t *testing.T) {
+	_, err := NewTemplate(new(bytes.Buffer), "")
+	assert.ErrorIs(t, err, ErrNoTemplate)
+
+	_, err = NewTemplate(new(bytes.Buffer), filepath.Join(t.TempDir(), "missing.tmpl"))
+	assert.Error(t, err)
+
+	_, err = NewTemplate(new(bytes.Buffer), writeTemplate(t, `{{range .Files}}`))
+	assert.Error(t, err)
+
+	p, err := NewPrinterWithOptions(OutFormatTemplate, new(bytes.Buffer), Options{Template: writeTemplate(t, `{{.Summary.Findings}}`)})
+	assert.NoError(t, err)
+	assert.IsType(t, &Template{}, p)
+
+	p, err = NewPrinter(OutFormatTemplate, new(bytes.Buffer))
+	assert.ErrorIs(t, err, ErrNoTemplate)
+	assert.Nil(t, p)
+}
@@ -0,0 +1,22 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Fingerprint returns a fingerprint of the Result that is based on the rule name
+// and the text of the line, rather than the line number, so it remains the same
+// when unrelated lines are added or removed from the file.
+// If the line is not available (ie, for path results or lines over MaxLineLength),
+// the reason is used instead.
+func Fingerprint(r Result) string {
+	text := strings.TrimSpace(r.GetLine())
+	if len(text) == 0 {
+		text = r.Reason()
+	}
+
+	sum := sha256.Sum256([]byte(r.GetRuleName() + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,27 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	rs1 := FindResults(&rule.TestRule, "my/file", "this has the term whitelist", 1)
+	rs2 := FindResults(&rule.TestRule, "my/file", "  this has the term whitelist", 10)
+	rs3 := FindResults(&rule.TestRule, "my/file", "this has the term whitelist too", 1)
+	rs4 := FindResults(&rule.TestErrorRule, "my/file", "this has the term slave", 1)
+
+	// line numbers and leading whitespace don't affect the fingerprint
+	assert.Equal(t, Fingerprint(rs1[0]), Fingerprint(rs2[0]))
+	assert.NotEqual(t, Fingerprint(rs1[0]), Fingerprint(rs3[0]))
+	assert.NotEqual(t, Fingerprint(rs1[0]), Fingerprint(rs4[0]))
+	assert.Len(t, Fingerprint(rs1[0]), 64)
+
+	// path results fall back to the reason
+	prs := MatchPath(&rule.TestRule, "my/whitelist")
+	assert.NotEmpty(t, Fingerprint(prs[0]))
+	assert.NotEqual(t, Fingerprint(prs[0]), Fingerprint(MatchPath(&rule.TestRule, "my/white-list")[0]))
+}
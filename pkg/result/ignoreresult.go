@@ -0,0 +1,64 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jdstrand/language-checker/pkg/rule"
+)
+
+// UnusedIgnoreRule is the rule of every IgnoreResult, so printers can show them like any other finding
+var UnusedIgnoreRule = &rule.Rule{
+	Name:     "unused-ignore",
+	Note:     "Remove the rule from the langcheckignore directive",
+	Severity: rule.SevWarn,
+}
+
+// IgnoreResult is a Result for a rule named by a langcheckignore:rule= directive that didn't ignore any findings,
+// or that isn't a known rule
+type IgnoreResult struct {
+	LineResult
+	// Unknown denotes that the rule named by the directive isn't one of the rules that were loaded
+	Unknown bool
+}
+
+// NewIgnoreResult returns an IgnoreResult for the rule name in the directive,
+// which is at startColumn to endColumn of the line
+func NewIgnoreResult(name, filename, text string, line, startColumn, endColumn int, unknown bool) IgnoreResult {
+	r := IgnoreResult{
+		LineResult: NewLineResult(UnusedIgnoreRule, name, filename, line, startColumn, endColumn),
+		Unknown:    unknown,
+	}
+	if len(text) < MaxLineLength {
+		r.Line = text
+	}
+	return r
+}
+
+// Reason is the reason the directive should be removed or fixed
+func (r IgnoreResult) Reason() string {
+	if r.Unknown {
+		return fmt.Sprintf("`%s` is not a known rule, so the langcheckignore directive has no effect", r.Finding)
+	}
+	return fmt.Sprintf("`%s` has no findings to ignore, so the langcheckignore directive can be removed", r.Finding)
+}
+
+func (r IgnoreResult) String() string {
+	pos := fmt.Sprintf("%s-%s",
+		r.StartPosition.String(),
+		r.EndPosition.String())
+	return fmt.Sprintf("    %-14s %-10s %s", pos, r.Rule.Severity, r.Reason())
+}
+
+// MarshalJSON override to include Reason in the json response
+func (r IgnoreResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		jsonLineResult
+		Unknown bool
+		Reason  string
+	}{
+		jsonLineResult: jsonLineResult(r.LineResult),
+		Unknown:        r.Unknown,
+		Reason:         r.Reason(),
+	})
+}
@@ -0,0 +1,28 @@
+package result
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreResult(t *testing.T) {
+	r := NewIgnoreResult("whitelist", "my/file", "ok # langcheckignore:rule=whitelist", 1, 26, 35, false)
+	assert.Equal(t, UnusedIgnoreRule.Name, r.GetRuleName())
+	assert.Equal(t, UnusedIgnoreRule.Severity, r.GetSeverity())
+	assert.Equal(t, "ok # langcheckignore:rule=whitelist", r.GetLine())
+	assert.Equal(t, "`whitelist` has no findings to ignore, so the langcheckignore directive can be removed", r.Reason())
+	assert.Equal(t, fmt.Sprintf("    my/file:1:26-my/file:1:35 warning    %s", r.Reason()), r.String())
+
+	r = NewIgnoreResult("whitelsit", "my/file", "ok # langcheckignore:rule=whitelsit", 1, 26, 35, true)
+	assert.Equal(t, "`whitelsit` is not a known rule, so the langcheckignore directive has no effect", r.Reason())
+}
+
+func TestIgnoreResult_MarshalJSON(t *testing.T) {
+	r := NewIgnoreResult("whitelsit", "my/file", "ok # langcheckignore:rule=whitelsit", 1, 26, 35, true)
+	b, err := r.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"Unknown":true`)
+	assert.Contains(t, string(b), fmt.Sprintf(`"Reason":"%s"`, r.Reason()))
+}
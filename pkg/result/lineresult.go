@@ -79,6 +79,9 @@ func (r LineResult) GetSeverity() rule.Severity { return r.Rule.Severity }
 // GetRuleName returns the rule name for the Result
 func (r LineResult) GetRuleName() string { return r.Rule.Name }
 
+// GetRule returns the rule that produced the Result
+func (r LineResult) GetRule() *rule.Rule { return r.Rule }
+
 // GetStartPosition returns the start position for the Result
 func (r LineResult) GetStartPosition() *token.Position { return r.StartPosition }
 
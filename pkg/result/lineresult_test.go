@@ -43,6 +43,11 @@ func TestLineResult_GetRuleName(t *testing.T) {
 	assert.Equal(t, lr.GetRuleName(), lr.Rule.Name)
 }
 
+func TestLineResult_GetRule(t *testing.T) {
+	lr := testLineResult()
+	assert.Equal(t, lr.GetRule(), lr.Rule)
+}
+
 func TestLineResult_GetStartPosition(t *testing.T) {
 	lr := testLineResult()
 	assert.Equal(t, lr.GetStartPosition(), lr.StartPosition)
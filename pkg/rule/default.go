@@ -9,6 +9,9 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// DefaultSource is the Source of the default rules
+const DefaultSource = "default"
+
 // DefaultRules are the default rules always used.
 // This will be populated by the embed package on init
 var DefaultRules = []*Rule{}
@@ -22,6 +25,7 @@ func init() {
 	}
 
 	for _, r := range DefaultRules {
+		r.Source = DefaultSource
 		r.SetRegexp()
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 func TestDefaultRules(t *testing.T) {
 	for _, r := range DefaultRules {
+		assert.Equal(t, DefaultSource, r.Source)
 		for _, term := range r.Terms {
 			t.Run(r.Name+"/"+term, func(t *testing.T) {
 				assert.Len(t, r.FindMatchIndexes(fmt.Sprintf("%s with other words after", term)), 1)
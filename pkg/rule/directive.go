@@ -0,0 +1,153 @@
+package rule
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// untilPattern matches the value of an until attribute. A date ends at the date, so the closer of a comment
+// such as until=2027-01-01*/ isn't part of it, and anything else is matched so it can be reported as invalid.
+const untilPattern = `(?:\d{4}-\d{2}-\d{2}|\S+)`
+
+// ignoreAttributesPattern matches the optional attributes that follow an ignore directive
+const ignoreAttributesPattern = `((?:[ \t]+(?:reason="[^"]*"|until=` + untilPattern + `))*)`
+
+var (
+	blockIgnoreRegex     = regexp.MustCompile(`langcheckignore:(begin|end|file)\b(?:[ \t]+rule=(\S+))?` + ignoreAttributesPattern)
+	ignoreAttributeRegex = regexp.MustCompile(`(reason)="([^"]*)"|(until)=(` + untilPattern + `)`)
+)
+
+// IgnoreAttributes are the optional attributes of an ignore directive, such as the reason and until in
+// langcheckignore:rule=slave reason="redis protocol" until=2027-01-01
+type IgnoreAttributes struct {
+	// Reason is why the findings are ignored
+	Reason string
+	// Until is the last day, as YYYY-MM-DD, that the findings are ignored
+	Until string
+}
+
+func parseIgnoreAttributes(s string) IgnoreAttributes {
+	var a IgnoreAttributes
+	for _, m := range ignoreAttributeRegex.FindAllStringSubmatch(s, -1) {
+		if m[1] == "reason" {
+			a.Reason = m[2]
+		} else if m[3] == "until" {
+			a.Until = m[4]
+		}
+	}
+	return a
+}
+
+// ValidUntil denotes if Until is empty or a valid date
+func (a IgnoreAttributes) ValidUntil() bool {
+	if a.Until == "" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", a.Until)
+	return err == nil
+}
+
+// Expired denotes if the directive no longer ignores findings at the time now,
+// which is after the day of Until in the local time zone.
+// An Until that isn't a valid date is always expired, so a typo doesn't ignore findings forever.
+// Use ValidUntil to report it.
+func (a IgnoreAttributes) Expired(now time.Time) bool {
+	if a.Until == "" {
+		return false
+	}
+	until, err := time.ParseInLocation("2006-01-02", a.Until, now.Location())
+	if err != nil {
+		return true
+	}
+	return !now.Before(until.AddDate(0, 0, 1))
+}
+
+// DirectiveKind is the kind of a block or file ignore directive
+type DirectiveKind int
+
+const (
+	// DirectiveBegin starts a region of lines that are ignored
+	DirectiveBegin DirectiveKind = iota
+	// DirectiveEnd ends the most recent region started by DirectiveBegin
+	DirectiveEnd
+	// DirectiveFile ignores the entire file
+	DirectiveFile
+)
+
+// Directive is a block or file ignore directive, such as langcheckignore:begin rule=whitelist
+type Directive struct {
+	Kind DirectiveKind
+	// Rules are the names of the rules that are ignored. If empty, all rules are ignored.
+	Rules []string
+	IgnoreAttributes
+}
+
+// ParseDirectives returns the block and file ignore directives in the line, in the order they appear.
+// For example, a line containing langcheckignore:begin rule=whitelist,blacklist starts a region where
+// findings for the `whitelist` and `blacklist` rules are ignored, until a line containing langcheckignore:end.
+func ParseDirectives(line string) []Directive {
+	matches := blockIgnoreRegex.FindAllStringSubmatch(line, -1)
+	if matches == nil {
+		return nil
+	}
+
+	directives := make([]Directive, 0, len(matches))
+	for _, m := range matches {
+		d := Directive{}
+		switch m[1] {
+		case "begin":
+			d.Kind = DirectiveBegin
+		case "end":
+			d.Kind = DirectiveEnd
+		case "file":
+			d.Kind = DirectiveFile
+		}
+		if m[2] != "" {
+			d.Rules = strings.Split(m[2], ",")
+		}
+		d.IgnoreAttributes = parseIgnoreAttributes(m[3])
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// Ignores denotes if the directive ignores findings for the rule
+func (d Directive) Ignores(r *Rule) bool {
+	if len(d.Rules) == 0 {
+		return true
+	}
+	for _, name := range d.Rules {
+		if name == r.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleIgnore is a rule named by a langcheckignore:rule= directive,
+// with the byte offsets of the name in the line
+type RuleIgnore struct {
+	Name  string
+	Start int
+	End   int
+	IgnoreAttributes
+}
+
+// ParseRuleIgnores returns the rules named by the langcheckignore:rule= directives in the line,
+// in the order they appear
+func ParseRuleIgnores(line string) []RuleIgnore {
+	var ignores []RuleIgnore
+	for _, m := range ignoreRuleRegex.FindAllStringSubmatchIndex(line, -1) {
+		start := m[2]
+		attrs := parseIgnoreAttributes(line[m[4]:m[5]])
+		for _, name := range strings.Split(line[m[2]:m[3]], ",") {
+			if name != "" {
+				ignores = append(ignores, RuleIgnore{Name: name, Start: start, End: start + len(name), IgnoreAttributes: attrs})
+			}
+			// skip the comma
+			start += len(name) + 1
+		}
+	}
+	return ignores
+}
@@ -0,0 +1,94 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDirectives(t *testing.T) {
+	tests := []struct {
+		desc     string
+		line     string
+		expected []Directive
+	}{
+		{"none", "no directives", nil},
+		{"inline ignore", "# langcheckignore:rule=whitelist", nil},
+		{"begin", "# langcheckignore:begin", []Directive{{Kind: DirectiveBegin}}},
+		{"begin with rules", "# langcheckignore:begin rule=whitelist,slave", []Directive{{Kind: DirectiveBegin, Rules: []string{"whitelist", "slave"}}}},
+		{"end", "/* langcheckignore:end */", []Directive{{Kind: DirectiveEnd}}},
+		{"file", "// langcheckignore:file rule=slave", []Directive{{Kind: DirectiveFile, Rules: []string{"slave"}}}},
+		{"end and begin", "langcheckignore:end langcheckignore:begin", []Directive{{Kind: DirectiveEnd}, {Kind: DirectiveBegin}}},
+		{"not a directive", "# langcheckignore:beginning", nil},
+		{"attributes", `# langcheckignore:begin rule=slave reason="redis protocol" until=2027-01-01`, []Directive{{Kind: DirectiveBegin, Rules: []string{"slave"}, IgnoreAttributes: IgnoreAttributes{Reason: "redis protocol", Until: "2027-01-01"}}}},
+		{"attributes without rule", `# langcheckignore:file reason="vendored"`, []Directive{{Kind: DirectiveFile, IgnoreAttributes: IgnoreAttributes{Reason: "vendored"}}}},
+		{"until in block comment", "/* langcheckignore:begin until=2027-01-01*/", []Directive{{Kind: DirectiveBegin, IgnoreAttributes: IgnoreAttributes{Until: "2027-01-01"}}}},
+		{"until in html comment", "<!-- langcheckignore:file until=2027-01-01-->", []Directive{{Kind: DirectiveFile, IgnoreAttributes: IgnoreAttributes{Until: "2027-01-01"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseDirectives(tt.line))
+		})
+	}
+}
+
+func TestDirective_Ignores(t *testing.T) {
+	r := &Rule{Name: "whitelist"}
+	assert.True(t, Directive{Kind: DirectiveBegin}.Ignores(r))
+	assert.True(t, Directive{Kind: DirectiveBegin, Rules: []string{"slave", "whitelist"}}.Ignores(r))
+	assert.False(t, Directive{Kind: DirectiveFile, Rules: []string{"slave"}}.Ignores(r))
+}
+
+func TestParseRuleIgnores(t *testing.T) {
+	tests := []struct {
+		desc     string
+		line     string
+		expected []RuleIgnore
+	}{
+		{"none", "no directives", nil},
+		{"block", "# langcheckignore:begin rule=whitelist", nil},
+		{"one rule", "# langcheckignore:rule=whitelist", []RuleIgnore{{Name: "whitelist", Start: 23, End: 32}}},
+		{"two rules", "#langcheckignore:rule=slave,whitelist", []RuleIgnore{{Name: "slave", Start: 22, End: 27}, {Name: "whitelist", Start: 28, End: 37}}},
+		{"empty rule", "#langcheckignore:rule=slave,,x", []RuleIgnore{{Name: "slave", Start: 22, End: 27}, {Name: "x", Start: 29, End: 30}}},
+		{"two directives", "langcheckignore:rule=a langcheckignore:rule=b", []RuleIgnore{{Name: "a", Start: 21, End: 22}, {Name: "b", Start: 44, End: 45}}},
+		{"attributes", `langcheckignore:rule=a,b until=2027-01-01 reason="redis protocol"`, []RuleIgnore{
+			{Name: "a", Start: 21, End: 22, IgnoreAttributes: IgnoreAttributes{Reason: "redis protocol", Until: "2027-01-01"}},
+			{Name: "b", Start: 23, End: 24, IgnoreAttributes: IgnoreAttributes{Reason: "redis protocol", Until: "2027-01-01"}},
+		}},
+		{"unquoted reason", "langcheckignore:rule=a reason=protocol", []RuleIgnore{{Name: "a", Start: 21, End: 22}}},
+		{"until in block comment", "/* langcheckignore:rule=a until=2027-01-01*/", []RuleIgnore{{Name: "a", Start: 24, End: 25, IgnoreAttributes: IgnoreAttributes{Until: "2027-01-01"}}}},
+		{"until in html comment", "<!-- langcheckignore:rule=a until=2027-01-01-->", []RuleIgnore{{Name: "a", Start: 26, End: 27, IgnoreAttributes: IgnoreAttributes{Until: "2027-01-01"}}}},
+		{"invalid until", "langcheckignore:rule=a until=soon", []RuleIgnore{{Name: "a", Start: 21, End: 22, IgnoreAttributes: IgnoreAttributes{Until: "soon"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseRuleIgnores(tt.line))
+		})
+	}
+}
+
+func TestIgnoreAttributes_Expired(t *testing.T) {
+	now := time.Date(2027, 1, 1, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		until    string
+		expected bool
+	}{
+		{"", false},
+		{"2027-01-02", false},
+		{"2027-01-01", false},
+		{"2026-12-31", true},
+		{"2027-13-01", true},
+		{"tomorrow", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, IgnoreAttributes{Until: tt.until}.Expired(now), tt.until)
+	}
+}
+
+func TestIgnoreAttributes_ValidUntil(t *testing.T) {
+	assert.True(t, IgnoreAttributes{}.ValidUntil())
+	assert.True(t, IgnoreAttributes{Until: "2027-01-01"}.ValidUntil())
+	assert.False(t, IgnoreAttributes{Until: "2027-13-01"}.ValidUntil())
+	assert.False(t, IgnoreAttributes{Until: "tomorrow"}.ValidUntil())
+}
@@ -0,0 +1,118 @@
+package rule
+
+import (
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// setTermRegexes populates a regex for each term, anchored to the start of the text,
+// sorted so the longest term is tried first
+func (r *Rule) setTermRegexes(terms []string) {
+	sorted := make([]string, len(terms))
+	copy(sorted, terms)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	r.termRes = make([]*regexp.Regexp, len(sorted))
+	for i, t := range sorted {
+		r.termRes[i] = regexp.MustCompile("^(?i)(?:" + t + ")")
+	}
+}
+
+// findIdentifierMatchIndexes returns the start and end indexes for all rule findings in the text,
+// where word boundaries include the boundaries between sub-words in identifiers,
+// such as camelCase, PascalCase, snake_case, SCREAMING_SNAKE_CASE and kebab-case.
+func (r *Rule) findIdentifierMatchIndexes(text string) [][]int {
+	start, end := r.wordBoundaries()
+
+	var idx [][]int
+	for pos := 0; pos < len(text); {
+		loc := r.re.FindStringIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		s := pos + loc[0]
+
+		if e := r.matchIdentifierAt(text, s, start, end); e > s {
+			idx = append(idx, []int{s, e})
+			pos = e
+			continue
+		}
+
+		// no term matched with the required boundaries, so keep looking from the next character
+		_, size := utf8.DecodeRuneInString(text[s:])
+		pos = s + size
+	}
+
+	if len(idx) == 0 {
+		return [][]int(nil)
+	}
+	return idx
+}
+
+// matchIdentifierAt returns the end index of the longest term at index s of the text,
+// which satisfies the required boundaries, or -1 if there is none
+func (r *Rule) matchIdentifierAt(text string, s int, start, end bool) int {
+	if start && !isIdentifierBoundary(text, s) {
+		return -1
+	}
+
+	for _, re := range r.termRes {
+		loc := re.FindStringIndex(text[s:])
+		if loc == nil || loc[1] == 0 {
+			continue
+		}
+		if e := s + loc[1]; !end || isIdentifierBoundary(text, e) {
+			return e
+		}
+	}
+	return -1
+}
+
+func (r *Rule) wordBoundaries() (start, end bool) {
+	if r.Options.WordBoundary {
+		return true, true
+	}
+	return r.Options.WordBoundaryStart, r.Options.WordBoundaryEnd
+}
+
+// isIdentifierBoundary returns true if index i of the text is a word boundary,
+// or a boundary between two sub-words of an identifier
+func isIdentifierBoundary(text string, i int) bool {
+	prev, next := rune(-1), rune(-1)
+	if i > 0 {
+		prev, _ = utf8.DecodeLastRuneInString(text[:i])
+	}
+	if i < len(text) {
+		next, _ = utf8.DecodeRuneInString(text[i:])
+	}
+
+	// underscores separate words in snake_case, so they aren't treated as word characters
+	if isWordRune(prev) != isWordRune(next) {
+		return true
+	}
+	if !isWordRune(prev) {
+		return false
+	}
+
+	switch {
+	case unicode.IsLower(prev) && unicode.IsUpper(next):
+		// camelCase
+		return true
+	case unicode.IsDigit(prev) != unicode.IsDigit(next):
+		// ipv4Address, Address4
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(next):
+		// an acronym followed by a word, such as HTTPServer
+		after, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(next):])
+		return unicode.IsLower(after)
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,70 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_FindMatchIndexesSplitIdentifiers(t *testing.T) {
+	tests := []struct {
+		desc     string
+		options  Options
+		text     string
+		expected [][]int
+	}{
+		{"camelCase", Options{WordBoundary: true}, "if whiteListedIPs.contains(ip)", [][]int{{3, 14}}},
+		{"PascalCase", Options{WordBoundary: true}, "type MasterNode struct{}", [][]int{{5, 11}}},
+		{"camelCase end", Options{WordBoundary: true}, "slaveCount := 1", [][]int{{0, 5}}},
+		{"snake_case", Options{WordBoundary: true}, "MASTER_NODE = 1", [][]int{{0, 6}}},
+		{"kebab-case", Options{WordBoundary: true}, "--master-node", [][]int{{2, 8}}},
+		{"acronym", Options{WordBoundary: true}, "HTTPMasterURL", [][]int{{4, 10}}},
+		{"digits", Options{WordBoundary: true}, "master2slave", [][]int{{0, 6}, {7, 12}}},
+		{"plain words", Options{WordBoundary: true}, "the master and the slave", [][]int{{4, 10}, {19, 24}}},
+		{"not a sub-word", Options{WordBoundary: true}, "mastermind remastered", [][]int(nil)},
+		{"not a sub-word upper", Options{WordBoundary: true}, "MASTERMIND", [][]int(nil)},
+		{"longer term with boundary", Options{WordBoundary: true}, "whitelistedHosts", [][]int{{0, 11}}},
+		{"shorter term with boundary", Options{WordBoundary: true}, "masterSlave", [][]int{{0, 6}, {6, 11}}},
+		{"start only", Options{WordBoundaryStart: true}, "mastermind xmaster", [][]int{{0, 6}}},
+		{"end only", Options{WordBoundaryEnd: true}, "mastermind xmaster", [][]int{{12, 18}}},
+		{"no boundaries", Options{}, "mastermind", [][]int{{0, 6}}},
+		{"unicode", Options{WordBoundary: true}, "élèveMaster", [][]int{{7, 13}}},
+		{"inline ignore", Options{WordBoundary: true}, "masterNode // langcheckignore:rule=master", [][]int{{0, 6}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := Rule{
+				Name:  "test",
+				Terms: []string{"master", "masters", "slave", "whitelist", "whitelisted"},
+			}
+			tt.options.SplitIdentifiers = true
+			r.SetOptions(tt.options)
+			assert.Equal(t, tt.expected, r.FindMatchIndexes(tt.text))
+		})
+	}
+}
+
+func Test_isIdentifierBoundary(t *testing.T) {
+	tests := []struct {
+		text     string
+		i        int
+		expected bool
+	}{
+		{"fooBar", 3, true},
+		{"fooBar", 2, false},
+		{"foo_bar", 3, true},
+		{"foo_bar", 4, true},
+		{"foo-bar", 3, true},
+		{"FOOBar", 3, true},
+		{"FOOBar", 2, false},
+		{"FOOBAR", 3, false},
+		{"foo1", 3, true},
+		{"foo", 0, true},
+		{"foo", 3, true},
+		{"", 0, false},
+		{"  ", 1, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, isIdentifierBoundary(tt.text, tt.i), "%q at %d", tt.text, tt.i)
+	}
+}
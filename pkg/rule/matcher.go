@@ -0,0 +1,188 @@
+package rule
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Matcher finds which rules may have findings in a line of text, with a single pass over the text
+// regardless of the number of rules. The terms of all rules are compiled into one Aho-Corasick automaton,
+// so each line only needs to be checked against the regex of the rules that have a term in the line,
+// instead of against the regex of every rule.
+type Matcher struct {
+	rules []*Rule
+	nodes []matcherNode
+	// always contains the rules that have to be checked on every line, such as rules with an empty term
+	always []int
+}
+
+type matcherNode struct {
+	next map[rune]int32
+	fail int32
+	// rules contains the index of each rule with a term that ends at this node
+	rules []int
+}
+
+// NewMatcher returns a Matcher for the rules provided. Disabled rules are never returned as candidates.
+func NewMatcher(rules []*Rule) *Matcher {
+	m := &Matcher{
+		rules: rules,
+		nodes: []matcherNode{{}},
+	}
+
+	for i, r := range rules {
+		if r.Disabled() {
+			continue
+		}
+
+		for _, t := range r.Terms {
+			if t == "" {
+				m.always = append(m.always, i)
+				break
+			}
+			m.add(t, i)
+		}
+	}
+	m.build()
+
+	return m
+}
+
+// add inserts a term into the trie, ending at a node that outputs the rule index i
+func (m *Matcher) add(term string, i int) {
+	n := int32(0)
+	for _, r := range term {
+		r = fold(r)
+		next, ok := m.nodes[n].next[r]
+		if !ok {
+			if m.nodes[n].next == nil {
+				m.nodes[n].next = map[rune]int32{}
+			}
+			m.nodes = append(m.nodes, matcherNode{})
+			next = int32(len(m.nodes) - 1)
+			m.nodes[n].next[r] = next
+		}
+		n = next
+	}
+
+	for _, ri := range m.nodes[n].rules {
+		if ri == i {
+			return
+		}
+	}
+	m.nodes[n].rules = append(m.nodes[n].rules, i)
+}
+
+// build populates the failure links of the trie with a breadth first traversal, and merges the
+// outputs of each node's failure node into its own, so that matching never has to follow failure links for output
+func (m *Matcher) build() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for r, child := range m.nodes[n].next {
+			f := m.nodes[n].fail
+			for {
+				if next, ok := m.nodes[f].next[r]; ok {
+					m.nodes[child].fail = next
+					break
+				}
+				if f == 0 {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+
+			m.nodes[child].rules = mergeIndexes(m.nodes[child].rules, m.nodes[m.nodes[child].fail].rules)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Candidates returns the rules that have at least one term in the text, in the same order as the rules provided to
+// NewMatcher. Terms are matched case insensitively and without word boundaries, so a candidate rule may still not
+// have any findings once the rule's own options are considered, but a rule that isn't a candidate never has any findings.
+func (m *Matcher) Candidates(text string) []*Rule {
+	found := make([]bool, len(m.rules))
+	count := 0
+	mark := func(indexes []int) {
+		for _, i := range indexes {
+			if !found[i] {
+				found[i] = true
+				count++
+			}
+		}
+	}
+	mark(m.always)
+
+	n := int32(0)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		r = fold(r)
+
+		for {
+			if next, ok := m.nodes[n].next[r]; ok {
+				n = next
+				break
+			}
+			if n == 0 {
+				break
+			}
+			n = m.nodes[n].fail
+		}
+		mark(m.nodes[n].rules)
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	candidates := make([]*Rule, 0, count)
+	for i, ok := range found {
+		if ok {
+			candidates = append(candidates, m.rules[i])
+		}
+	}
+	return candidates
+}
+
+// fold returns the smallest rune that is equivalent to r under simple case folding,
+// which is how a case insensitive regex compares runes
+func fold(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	}
+
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+func mergeIndexes(a, b []int) []int {
+	for _, i := range b {
+		exists := false
+		for _, j := range a {
+			if i == j {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			a = append(a, i)
+		}
+	}
+	return a
+}
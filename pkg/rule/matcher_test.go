@@ -0,0 +1,89 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ruleNames(rules []*Rule) []string {
+	var names []string
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestMatcher_Candidates(t *testing.T) {
+	rules := []*Rule{
+		{Name: "whitelist", Terms: []string{"whitelist", "white-list"}},
+		{Name: "master", Terms: []string{"master"}},
+		{Name: "master-slave", Terms: []string{"master-slave", "master/slave"}},
+		{Name: "slave", Terms: []string{"slave"}},
+		{Name: "disabled"},
+		{Name: "cpp", Terms: []string{"c++"}},
+	}
+	m := NewMatcher(rules)
+
+	tests := []struct {
+		desc     string
+		text     string
+		expected []string
+	}{
+		{"none", "this line is fine", nil},
+		{"one", "add it to the whitelist", []string{"whitelist"}},
+		{"case insensitive", "WHITE-LIST", []string{"whitelist"}},
+		{"overlapping terms", "the master/slave setup", []string{"master", "master-slave", "slave"}},
+		{"rule order", "slave and master", []string{"master", "slave"}},
+		{"no word boundary", "mastermind", []string{"master"}},
+		{"suffix of another term", "masterslave", []string{"master", "slave"}},
+		{"regex metacharacters", "written in C++", []string{"cpp"}},
+		{"unicode case folding", "the maſter", []string{"master"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ruleNames(m.Candidates(tt.text)))
+		})
+	}
+}
+
+func TestMatcher_EmptyTerm(t *testing.T) {
+	m := NewMatcher([]*Rule{
+		{Name: "master", Terms: []string{"master"}},
+		{Name: "empty", Terms: []string{""}},
+	})
+	assert.Equal(t, []string{"empty"}, ruleNames(m.Candidates("this line is fine")))
+}
+
+// Every rule with findings in the text must be a candidate
+func TestMatcher_CandidatesDefaultRules(t *testing.T) {
+	m := NewMatcher(DefaultRules)
+
+	lines := []string{
+		"the master branch is a whitelist of slaves",
+		"Blacklisted IPs are sent to the dummy value",
+		"a sanity check for the grandfathered MASTER_NODE",
+		"this line is fine",
+	}
+	for _, line := range lines {
+		candidates := map[string]bool{}
+		for _, r := range m.Candidates(line) {
+			candidates[r.Name] = true
+		}
+		for _, r := range DefaultRules {
+			if len(r.FindMatchIndexes(line)) > 0 {
+				assert.True(t, candidates[r.Name], "%s should be a candidate for %q", r.Name, line)
+			}
+		}
+	}
+}
+
+func Test_fold(t *testing.T) {
+	assert.Equal(t, 'A', fold('a'))
+	assert.Equal(t, 'A', fold('A'))
+	assert.Equal(t, 'K', fold('\u212A')) // Kelvin sign
+	assert.Equal(t, 'K', fold('k'))
+	assert.Equal(t, 'S', fold('ſ'))
+	assert.Equal(t, fold('é'), fold('É'))
+	assert.Equal(t, '-', fold('-'))
+}
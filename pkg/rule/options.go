@@ -5,6 +5,45 @@ type Options struct {
 	WordBoundary      bool     `yaml:"word_boundary"`
 	WordBoundaryStart bool     `yaml:"word_boundary_start"`
 	WordBoundaryEnd   bool     `yaml:"word_boundary_end"`
+	SplitIdentifiers  bool     `yaml:"split_identifiers" json:",omitempty"`
 	IncludeNote       *bool    `yaml:"include_note"`
+	Scope             *Scope   `yaml:"scope" json:",omitempty"`
 	Categories        []string `yaml:"categories"`
+	// IncludePaths, if set, are gitignore-style patterns of the only files that the rule checks
+	IncludePaths []string `yaml:"include_paths" json:",omitempty"`
+	// ExcludePaths are gitignore-style patterns of files that the rule doesn't check
+	ExcludePaths []string `yaml:"exclude_paths" json:",omitempty"`
+}
+
+// override returns the options o overridden by the options in other that are set, by their yaml name
+// prefixed with "options."
+func (o Options) override(other Options, set map[string]bool) Options {
+	if set["options.word_boundary"] {
+		o.WordBoundary = other.WordBoundary
+	}
+	if set["options.word_boundary_start"] {
+		o.WordBoundaryStart = other.WordBoundaryStart
+	}
+	if set["options.word_boundary_end"] {
+		o.WordBoundaryEnd = other.WordBoundaryEnd
+	}
+	if set["options.split_identifiers"] {
+		o.SplitIdentifiers = other.SplitIdentifiers
+	}
+	if set["options.include_note"] {
+		o.IncludeNote = other.IncludeNote
+	}
+	if set["options.scope"] {
+		o.Scope = other.Scope
+	}
+	if set["options.categories"] {
+		o.Categories = other.Categories
+	}
+	if set["options.include_paths"] {
+		o.IncludePaths = other.IncludePaths
+	}
+	if set["options.exclude_paths"] {
+		o.ExcludePaths = other.ExcludePaths
+	}
+	return o
 }
@@ -0,0 +1,65 @@
+package rule
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/get-woke/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/jdstrand/language-checker/pkg/util"
+)
+
+// newPathMatcher returns a matcher for the gitignore-style patterns, or nil if there are no patterns
+func newPathMatcher(patterns []string) gitignore.Matcher {
+	if len(patterns) == 0 {
+		return nil
+	}
+	ps := make([]gitignore.Pattern, len(patterns))
+	for i, p := range patterns {
+		ps[i] = gitignore.ParsePattern(p, nil)
+	}
+	return gitignore.NewMatcher(ps)
+}
+
+// HasPaths denotes if the rule only applies to some paths, because it has include_paths or exclude_paths
+func (r *Rule) HasPaths() bool {
+	return len(r.Options.IncludePaths) > 0 || len(r.Options.ExcludePaths) > 0
+}
+
+// AppliesToPath denotes if the rule checks the file at the path, which is relative to the current directory.
+// Absolute paths in the current directory are made relative to it, so they match the same patterns.
+// The file must match one of the rule's include_paths, if it has any, and none of its exclude_paths.
+func (r *Rule) AppliesToPath(filename string) bool {
+	if !r.HasPaths() {
+		return true
+	}
+
+	r.SetRegexp()
+
+	parts := util.FilterEmptyStrings(strings.Split(path.Clean(filepath.ToSlash(relativePath(filename))), "/"))
+	if r.includePaths != nil && !r.includePaths.Match(parts, false) {
+		return false
+	}
+	if r.excludePaths != nil && r.excludePaths.Match(parts, false) {
+		return false
+	}
+	return true
+}
+
+// relativePath returns the absolute path relative to the current directory,
+// or the path unchanged if it's relative or outside the current directory
+func relativePath(filename string) string {
+	if !filepath.IsAbs(filename) {
+		return filename
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+	if rel, err := filepath.Rel(cwd, filename); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return rel
+	}
+	return filename
+}
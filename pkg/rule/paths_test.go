@@ -0,0 +1,45 @@
+package rule
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_AppliesToPath(t *testing.T) {
+	tests := []struct {
+		desc     string
+		options  Options
+		filename string
+		expected bool
+	}{
+		{"no paths", Options{}, "any/file.txt", true},
+		{"included", Options{IncludePaths: []string{"docs/**"}}, "docs/guide/intro.md", true},
+		{"included with dot", Options{IncludePaths: []string{"docs/**"}}, "./docs/intro.md", true},
+		{"not included", Options{IncludePaths: []string{"docs/**"}}, "src/main.go", false},
+		{"included by name", Options{IncludePaths: []string{"*.md"}}, "src/README.md", true},
+		{"one of many included", Options{IncludePaths: []string{"docs/**", "*.txt"}}, "notes.txt", true},
+		{"excluded", Options{ExcludePaths: []string{"vendor/redis/**"}}, "vendor/redis/conn.go", false},
+		{"not excluded", Options{ExcludePaths: []string{"vendor/redis/**"}}, "vendor/other/conn.go", true},
+		{"negated exclude", Options{ExcludePaths: []string{"vendor/**", "!vendor/ours/**"}}, "vendor/ours/conn.go", true},
+		{"included and excluded", Options{IncludePaths: []string{"docs/**"}, ExcludePaths: []string{"docs/generated/**"}}, "docs/generated/api.md", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := testRuleWithOptions(tt.options)
+			assert.Equal(t, tt.expected, r.AppliesToPath(tt.filename))
+		})
+	}
+}
+
+func TestRule_AppliesToAbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	r := testRuleWithOptions(Options{IncludePaths: []string{"docs/**"}})
+	assert.True(t, r.AppliesToPath(filepath.Join(dir, "docs", "a.md")))
+	assert.False(t, r.AppliesToPath(filepath.Join(dir, "src", "docs.go")))
+	// files outside the current directory keep their absolute path
+	assert.False(t, r.AppliesToPath(filepath.Join(filepath.Dir(dir), "docs", "a.md")))
+}
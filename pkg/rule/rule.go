@@ -6,9 +6,12 @@ import (
 	"strings"
 
 	"github.com/jdstrand/language-checker/pkg/util"
+
+	"github.com/get-woke/go-git/v5/plumbing/format/gitignore"
+	"gopkg.in/yaml.v2"
 )
 
-var ignoreRuleRegex = regexp.MustCompile(`langcheckignore:rule=(\S+)`)
+var ignoreRuleRegex = regexp.MustCompile(`langcheckignore:rule=(\S+)` + ignoreAttributesPattern)
 
 const wordBoundary = `\b`
 
@@ -17,11 +20,107 @@ type Rule struct {
 	Name         string   `yaml:"name"`
 	Terms        []string `yaml:"terms"`
 	Alternatives []string `yaml:"alternatives"`
+	Exceptions   []string `yaml:"exceptions" json:",omitempty"`
 	Note         string   `yaml:"note"`
 	Severity     Severity `yaml:"severity"`
 	Options      Options  `yaml:"options"`
+	// Enabled disables the rule if it is false
+	Enabled *bool `yaml:"enabled" json:",omitempty"`
+	// Source is where the rule is defined: DefaultSource, or the path or URL of a config file
+	Source string `yaml:"-" json:"-"`
+
+	re          *regexp.Regexp
+	termRes     []*regexp.Regexp
+	exceptionRe *regexp.Regexp
+
+	includePaths gitignore.Matcher
+	excludePaths gitignore.Matcher
 
-	re *regexp.Regexp
+	// fields are the yaml fields set for a rule without terms, which only overrides these fields
+	// of the rule with the same name. Options are prefixed with "options.".
+	fields map[string]bool
+}
+
+// compile-time check that Rule satisfies the yaml Unmarshaler
+var _ yaml.Unmarshaler = (*Rule)(nil)
+
+// UnmarshalYAML unmarshals the rule, and records the fields that are set if it has no terms
+func (r *Rule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Rule
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	if _, ok := fields["terms"]; ok {
+		return nil
+	}
+
+	r.fields = map[string]bool{}
+	for name, value := range fields {
+		r.fields[name] = true
+		if options, ok := value.(map[interface{}]interface{}); ok && name == "options" {
+			for option := range options {
+				r.fields[fmt.Sprintf("options.%v", option)] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Override returns the rule r overridden by o, a rule with the same name.
+// If o has terms, it replaces r. Otherwise, only the fields that are set in o are overridden,
+// so a rule can be disabled with enabled: false, or given a different severity, without repeating its terms.
+// A rule with only a name also replaces r, which disables it because it has no terms.
+func (r *Rule) Override(o *Rule) *Rule {
+	if !o.partial() {
+		return o
+	}
+
+	merged := *r
+	merged.re, merged.termRes, merged.exceptionRe = nil, nil, nil
+	merged.includePaths, merged.excludePaths = nil, nil
+	merged.fields = nil
+	if r.partial() {
+		// r is also an override, so merged is still an override of the rule with the same name
+		merged.fields = make(map[string]bool, len(r.fields)+len(o.fields))
+		for f := range r.fields {
+			merged.fields[f] = true
+		}
+		for f := range o.fields {
+			merged.fields[f] = true
+		}
+	}
+	if r.Source != "" && o.Source != "" {
+		merged.Source = fmt.Sprintf("%s, overridden by %s", r.Source, o.Source)
+	}
+
+	set := o.fields
+	if set["alternatives"] {
+		merged.Alternatives = o.Alternatives
+	}
+	if set["exceptions"] {
+		merged.Exceptions = o.Exceptions
+	}
+	if set["note"] {
+		merged.Note = o.Note
+	}
+	if set["severity"] {
+		merged.Severity = o.Severity
+	}
+	if set["enabled"] {
+		merged.Enabled = o.Enabled
+	}
+	merged.Options = merged.Options.override(o.Options, set)
+	return &merged
+}
+
+// partial denotes if the rule is an override that only sets some fields of the rule with the same name
+func (r *Rule) partial() bool {
+	return len(r.fields) > 1
 }
 
 // FindMatchIndexes returns the start and end indexes for all rule findings for the text supplied.
@@ -33,7 +132,20 @@ func (r *Rule) FindMatchIndexes(text string) [][]int {
 	r.SetRegexp()
 
 	// Remove inline ignores from text to avoid matching against other rules
-	matches := r.re.FindAllStringSubmatchIndex(maskInlineIgnore(text), -1)
+	text = maskInlineIgnore(text)
+
+	var idx [][]int
+	if r.Options.SplitIdentifiers {
+		idx = r.findIdentifierMatchIndexes(text)
+	} else {
+		idx = r.findMatchIndexes(text)
+	}
+
+	return r.removeExceptions(text, idx)
+}
+
+func (r *Rule) findMatchIndexes(text string) [][]int {
+	matches := r.re.FindAllStringSubmatchIndex(text, -1)
 	if matches == nil {
 		return [][]int(nil)
 	}
@@ -81,8 +193,50 @@ func (r *Rule) SetOptions(o Options) {
 }
 
 func (r *Rule) setRegex() {
-	group := strings.Join(escape(r.Terms), "|")
+	terms := escape(r.Terms)
+	group := strings.Join(terms, "|")
 	r.re = regexp.MustCompile(fmt.Sprintf(r.regexString(), group))
+
+	r.termRes = nil
+	if r.Options.SplitIdentifiers {
+		r.setTermRegexes(terms)
+	}
+
+	r.exceptionRe = nil
+	if len(r.Exceptions) > 0 {
+		exceptions := make([]string, len(r.Exceptions))
+		for i, e := range r.Exceptions {
+			exceptions[i] = regexp.QuoteMeta(e)
+		}
+		r.exceptionRe = regexp.MustCompile("(?i)" + strings.Join(exceptions, "|"))
+	}
+
+	r.includePaths = newPathMatcher(r.Options.IncludePaths)
+	r.excludePaths = newPathMatcher(r.Options.ExcludePaths)
+}
+
+// removeExceptions removes the matches that overlap with any of the rule's Exceptions in the text
+func (r *Rule) removeExceptions(text string, idx [][]int) [][]int {
+	if r.exceptionRe == nil || len(idx) == 0 {
+		return idx
+	}
+
+	exceptions := r.exceptionRe.FindAllStringIndex(text, -1)
+	if exceptions == nil {
+		return idx
+	}
+
+	var filtered [][]int
+MatchLoop:
+	for _, m := range idx {
+		for _, e := range exceptions {
+			if m[0] < e[1] && e[0] < m[1] {
+				continue MatchLoop
+			}
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
 }
 
 func (r *Rule) regexString() string {
@@ -95,6 +249,12 @@ func (r *Rule) regexString() string {
 		return s.String()
 	}
 
+	// word boundaries for split identifiers are checked after matching,
+	// since they can't be expressed with RE2
+	if r.Options.SplitIdentifiers {
+		return regex("", "")
+	}
+
 	if r.Options.WordBoundary {
 		return regex(wordBoundary, wordBoundary)
 	}
@@ -189,41 +349,41 @@ func IsDirectiveOnlyLine(line string) bool {
 	return !util.ContainsAlphanumeric(leftText)
 }
 
+// escape returns a copy of ss with all regex metacharacters escaped.
+// The original slice is not modified, so Terms remain usable as plain text.
 func escape(ss []string) []string {
+	escaped := make([]string, len(ss))
 	for i, s := range ss {
-		ss[i] = regexp.QuoteMeta(s)
+		escaped[i] = regexp.QuoteMeta(s)
 	}
-	return ss
+	return escaped
 }
 
-// maskInlineIgnore removes the entire match of the ignoreRuleRegex from the line
-// and replaces it with the null terminator (\x00) character so the rule matcher won't
-// attempt to find findings within the inline ignore
+// maskInlineIgnore removes the entire match of the ignoreRuleRegex, and any block or file directives,
+// from the line and replaces them with the null terminator (\x00) character so the rule matcher won't
+// attempt to find findings within the inline ignore.
+// Bytes are replaced rather than runes, so the index of every finding in the line is unchanged.
 func maskInlineIgnore(line string) string {
-	inlineIgnoreMatch := ignoreRuleRegex.FindStringIndex(line)
-	if inlineIgnoreMatch == nil || len(inlineIgnoreMatch) < 2 {
+	matches := ignoreRuleRegex.FindAllStringIndex(line, -1)
+	matches = append(matches, blockIgnoreRegex.FindAllStringIndex(line, -1)...)
+	if len(matches) == 0 {
 		return line
 	}
 
-	lineWithoutIgnoreRule := []rune(line)
-
-	start := inlineIgnoreMatch[0]
-	end := inlineIgnoreMatch[1]
-
-	for i := start; i < end; i++ {
-		// use null terminator to indicate a masked character
-		lineWithoutIgnoreRule[i] = rune(0)
+	lineWithoutIgnoreRule := []byte(line)
+	for _, m := range matches {
+		for i := m[0]; i < m[1]; i++ {
+			// use null terminator to indicate a masked character
+			lineWithoutIgnoreRule[i] = 0
+		}
 	}
 
 	return string(lineWithoutIgnoreRule)
 }
 
-// Disabled denotes if the rule is disabled
-// If no terms are provided, this essentially disables the rule
-// which is helpful for disabling default rules. Eventually, there should be a better
-// way to disable a default rule, and then, if a rule has no Terms, it falls back to the Name.
+// Disabled denotes if the rule is disabled, because enabled is false or it has no terms
 func (r *Rule) Disabled() bool {
-	return len(r.Terms) == 0
+	return (r.Enabled != nil && !*r.Enabled) || len(r.Terms) == 0
 }
 
 // SetIncludeNote populates IncludeNote attributte in Options
@@ -237,6 +397,24 @@ func (r *Rule) SetIncludeNote(includeNote bool) {
 	r.Options.IncludeNote = &includeNote
 }
 
+// SetScope populates Scope attribute in Options
+// If "scope" is already defined for the rule in yaml, it will not be overridden
+func (r *Rule) SetScope(scope Scope) {
+	if r.Options.Scope != nil {
+		return
+	}
+
+	r.Options.Scope = &scope
+}
+
+// Scope returns the part of a file that the rule checks, which is the entire file if not set
+func (r *Rule) Scope() Scope {
+	if r.Options.Scope != nil {
+		return *r.Options.Scope
+	}
+	return ScopeAll
+}
+
 // ContainsCategory denotes if the provided category exists in the rule's Options.Categories
 func (r *Rule) ContainsCategory(cat string) bool {
 	for _, ruleCat := range r.Options.Categories {
@@ -1,9 +1,11 @@
 package rule
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func testRuleWithOptions(o Options) Rule {
@@ -154,6 +156,12 @@ func TestRule_regexString(t *testing.T) {
 			rule:     testRuleWithOptions(Options{WordBoundary: true, WordBoundaryStart: false, WordBoundaryEnd: false}),
 			expected: `(?i)\b(%s)\b`,
 		},
+		{
+			// word boundaries are checked after matching when splitting identifiers
+			desc:     "split identifiers with word boundary",
+			rule:     testRuleWithOptions(Options{WordBoundary: true, SplitIdentifiers: true}),
+			expected: `(?i)(%s)`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -173,6 +181,16 @@ func Test_maskInlineIgnore(t *testing.T) {
 			line:     "langcheckignore:rule=master-slave",
 			expected: "\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
 		},
+		{
+			desc:     "replace langcheckignore:begin",
+			line:     "x #langcheckignore:begin rule=slave",
+			expected: "x #\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
+		},
+		{
+			desc:     "replace langcheckignore:rule with attributes",
+			line:     `langcheckignore:rule=a reason="slave"`,
+			expected: strings.Repeat("\x00", 37),
+		},
 		{
 			desc:     "not replace langcheckignore:rule",
 			line:     "no inline ignore",
@@ -235,3 +253,102 @@ func Test_IsDirectiveOnlyLine(t *testing.T) {
 		})
 	}
 }
+
+func TestRule_Exceptions(t *testing.T) {
+	tests := []struct {
+		desc     string
+		text     string
+		expected [][]int
+	}{
+		{"no exception", "the master branch", [][]int{{4, 10}}},
+		{"exception phrase", "she has a Master of Science", [][]int(nil)},
+		{"exception word", "email the webmaster about the master branch", [][]int{{30, 36}}},
+		{"exception case insensitive", "MASTER KEY rotation", [][]int(nil)},
+		{"exception is the whole text", "master key", [][]int(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := Rule{
+				Name:       "master",
+				Terms:      []string{"master"},
+				Exceptions: []string{"master of science", "webmaster", "master key"},
+			}
+			assert.Equal(t, tt.expected, r.FindMatchIndexes(tt.text))
+		})
+	}
+
+	r := Rule{Name: "master", Terms: []string{"master"}, Exceptions: []string{"webmaster"}}
+	r.SetOptions(Options{WordBoundary: true, SplitIdentifiers: true})
+	assert.Equal(t, [][]int{{13, 19}}, r.FindMatchIndexes("webmasterUrl masterUrl"))
+}
+
+func TestRule_Enabled(t *testing.T) {
+	r := testRule()
+	assert.False(t, r.Disabled())
+	assert.Len(t, r.FindMatchIndexes("this has rule1"), 1)
+
+	enabled := false
+	r.Enabled = &enabled
+	assert.True(t, r.Disabled())
+	assert.Len(t, r.FindMatchIndexes("this has rule1"), 0)
+}
+
+func TestRule_Override(t *testing.T) {
+	unmarshal := func(t *testing.T, s string) *Rule {
+		var r Rule
+		assert.NoError(t, yaml.Unmarshal([]byte(s), &r))
+		return &r
+	}
+	base := testRule()
+	base.Note = "a note"
+	base.Source = DefaultSource
+	base.Options.Categories = []string{"general"}
+
+	t.Run("terms replace the rule", func(t *testing.T) {
+		o := unmarshal(t, "name: rule1\nterms: [other]\nseverity: info\n")
+		assert.Same(t, o, base.Override(o))
+	})
+
+	t.Run("name only replaces the rule", func(t *testing.T) {
+		o := unmarshal(t, "name: rule1\n")
+		assert.Same(t, o, base.Override(o))
+		assert.True(t, o.Disabled())
+	})
+
+	t.Run("fields are overridden", func(t *testing.T) {
+		o := unmarshal(t, "name: rule1\nseverity: error\noptions:\n  word_boundary: true\n")
+		o.Source = "langcheck.yaml"
+		r := base.Override(o)
+		assert.Equal(t, SevError, r.Severity)
+		assert.True(t, r.Options.WordBoundary)
+		assert.Equal(t, base.Terms, r.Terms)
+		assert.Equal(t, base.Alternatives, r.Alternatives)
+		assert.Equal(t, base.Note, r.Note)
+		assert.Equal(t, base.Options.Categories, r.Options.Categories)
+		assert.Equal(t, "default, overridden by langcheck.yaml", r.Source)
+		assert.Len(t, r.FindMatchIndexes("rule1 and xrule1"), 1)
+
+		// the base rule isn't modified
+		assert.Equal(t, SevWarn, base.Severity)
+		assert.False(t, base.Options.WordBoundary)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		o := unmarshal(t, "name: rule1\nenabled: false\n")
+		r := base.Override(o)
+		assert.True(t, r.Disabled())
+		assert.Equal(t, base.Terms, r.Terms)
+
+		assert.False(t, base.Disabled())
+	})
+
+	t.Run("override of an override", func(t *testing.T) {
+		// such as an extended config and the config that extends it, which both override a default rule
+		o := unmarshal(t, "name: rule1\nenabled: false\n").Override(unmarshal(t, "name: rule1\nseverity: info\n"))
+		r := base.Override(o)
+		assert.NotSame(t, o, r)
+		assert.True(t, r.Disabled())
+		assert.Equal(t, SevInfo, r.Severity)
+		assert.Equal(t, base.Terms, r.Terms)
+	})
+}
@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Scope is the part of a file that a rule checks for findings
+type Scope int
+
+const (
+	// ScopeAll checks the entire file
+	// This will be the default scope
+	ScopeAll Scope = iota
+	// ScopeComments only checks comments
+	ScopeComments
+	// ScopeStrings only checks string literals
+	ScopeStrings
+	// ScopeCommentsAndStrings checks both comments and string literals
+	ScopeCommentsAndStrings
+)
+
+var scopeNames = [...]string{"all", "comments", "strings", "comments_and_strings"}
+
+// NewScope turns a string into a Scope
+func NewScope(s string) (Scope, error) {
+	for i, name := range scopeNames {
+		if s == name {
+			return Scope(i), nil
+		}
+	}
+	return ScopeAll, fmt.Errorf("invalid scope %q, must be one of: %s", s, strings.Join(scopeNames[:], ", "))
+}
+
+func (s Scope) String() string {
+	if int(s) < 0 || int(s) >= len(scopeNames) {
+		return scopeNames[ScopeAll]
+	}
+	return scopeNames[s]
+}
+
+// Comments denotes if comments are checked in the Scope
+func (s Scope) Comments() bool {
+	return s == ScopeComments || s == ScopeCommentsAndStrings
+}
+
+// Strings denotes if string literals are checked in the Scope
+func (s Scope) Strings() bool {
+	return s == ScopeStrings || s == ScopeCommentsAndStrings
+}
+
+// compile-time check that Scope satisfies the yaml Unmarshaler
+var _ yaml.Unmarshaler = (*Scope)(nil)
+
+// UnmarshalYAML to unmarshal scope string
+func (s *Scope) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	scope, err := NewScope(str)
+	if err != nil {
+		return err
+	}
+	*s = scope
+
+	return nil
+}
+
+// compile-time check that Scope satisfies the json Marshaler
+var _ json.Marshaler = (*Scope)(nil)
+
+// MarshalJSON to marshal Scope as a string
+func (s *Scope) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
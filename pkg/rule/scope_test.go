@@ -0,0 +1,62 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestScope_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Scope
+	}{
+		{"all", ScopeAll},
+		{"comments", ScopeComments},
+		{"strings", ScopeStrings},
+		{"comments_and_strings", ScopeCommentsAndStrings},
+	}
+	for _, test := range tests {
+		scope := new(Scope)
+		err := yaml.Unmarshal([]byte(test.input), &scope)
+		assert.NoError(t, err)
+
+		assert.Equalf(t, test.expected, *scope, "expected: %s, got: %s", test.expected, scope)
+	}
+
+	scope := new(Scope)
+	assert.EqualError(t, yaml.Unmarshal([]byte("code"), &scope), `invalid scope "code", must be one of: all, comments, strings, comments_and_strings`)
+}
+
+func TestScope_MarshalJSON(t *testing.T) {
+	for _, s := range []Scope{ScopeAll, ScopeComments, ScopeStrings, ScopeCommentsAndStrings} {
+		b, err := s.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+s.String()+`"`, string(b))
+	}
+	assert.Equal(t, "all", Scope(99).String())
+}
+
+func TestScope_CommentsStrings(t *testing.T) {
+	assert.False(t, ScopeAll.Comments())
+	assert.False(t, ScopeAll.Strings())
+	assert.True(t, ScopeComments.Comments())
+	assert.False(t, ScopeComments.Strings())
+	assert.False(t, ScopeStrings.Comments())
+	assert.True(t, ScopeStrings.Strings())
+	assert.True(t, ScopeCommentsAndStrings.Comments())
+	assert.True(t, ScopeCommentsAndStrings.Strings())
+}
+
+func TestRule_SetScope(t *testing.T) {
+	r := testRule()
+	assert.Equal(t, ScopeAll, r.Scope())
+
+	r.SetScope(ScopeComments)
+	assert.Equal(t, ScopeComments, r.Scope())
+
+	// Scope is not overridden once set
+	r.SetScope(ScopeStrings)
+	assert.Equal(t, ScopeComments, r.Scope())
+}
@@ -0,0 +1,129 @@
+package tokenizer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+var (
+	cStrings = []Delimiter{
+		{Open: `"`, Close: `"`, Escape: true},
+		{Open: `'`, Close: `'`, Escape: true},
+	}
+	cComments = []Delimiter{
+		{Open: "/*", Close: "*/"},
+	}
+)
+
+// Go is the syntax of Go
+var Go = &Language{
+	Name:          "go",
+	LineComments:  []string{"//"},
+	BlockComments: cComments,
+	Strings: []Delimiter{
+		{Open: "`", Close: "`", Multiline: true},
+		{Open: `"`, Close: `"`, Escape: true},
+		{Open: `'`, Close: `'`, Escape: true},
+	},
+}
+
+// Python is the syntax of Python
+var Python = &Language{
+	Name:         "python",
+	LineComments: []string{"#"},
+	Strings: []Delimiter{
+		{Open: `"""`, Close: `"""`, Escape: true, Multiline: true},
+		{Open: `'''`, Close: `'''`, Escape: true, Multiline: true},
+		{Open: `"`, Close: `"`, Escape: true},
+		{Open: `'`, Close: `'`, Escape: true},
+	},
+}
+
+// JavaScript is the syntax of JavaScript and TypeScript
+var JavaScript = &Language{
+	Name:          "javascript",
+	LineComments:  []string{"//"},
+	BlockComments: cComments,
+	Strings: []Delimiter{
+		{Open: "`", Close: "`", Escape: true, Multiline: true},
+		{Open: `"`, Close: `"`, Escape: true},
+		{Open: `'`, Close: `'`, Escape: true},
+	},
+}
+
+// Java is the syntax of Java
+var Java = &Language{
+	Name:          "java",
+	LineComments:  []string{"//"},
+	BlockComments: cComments,
+	Strings: []Delimiter{
+		{Open: `"""`, Close: `"""`, Escape: true, Multiline: true},
+		{Open: `"`, Close: `"`, Escape: true},
+		{Open: `'`, Close: `'`, Escape: true},
+	},
+}
+
+// C is the syntax of C and C++
+var C = &Language{
+	Name:          "c",
+	LineComments:  []string{"//"},
+	BlockComments: cComments,
+	Strings:       cStrings,
+}
+
+// Shell is the syntax of POSIX shells, bash and zsh
+var Shell = &Language{
+	Name:         "shell",
+	LineComments: []string{"#"},
+	Strings: []Delimiter{
+		{Open: `"`, Close: `"`, Escape: true, Multiline: true},
+		{Open: `'`, Close: `'`, Multiline: true},
+	},
+	CommentAfterSpace: true,
+}
+
+// YAML is the syntax of YAML
+var YAML = &Language{
+	Name:         "yaml",
+	LineComments: []string{"#"},
+	Strings: []Delimiter{
+		{Open: `"`, Close: `"`, Escape: true, Multiline: true},
+		{Open: `'`, Close: `'`, Multiline: true},
+	},
+	CommentAfterSpace: true,
+	PlainScalars:      true,
+}
+
+var extensions = map[string]*Language{
+	".go":   Go,
+	".py":   Python,
+	".pyi":  Python,
+	".js":   JavaScript,
+	".jsx":  JavaScript,
+	".mjs":  JavaScript,
+	".cjs":  JavaScript,
+	".ts":   JavaScript,
+	".tsx":  JavaScript,
+	".mts":  JavaScript,
+	".cts":  JavaScript,
+	".java": Java,
+	".c":    C,
+	".h":    C,
+	".cc":   C,
+	".cpp":  C,
+	".cxx":  C,
+	".hh":   C,
+	".hpp":  C,
+	".hxx":  C,
+	".sh":   Shell,
+	".bash": Shell,
+	".zsh":  Shell,
+	".yaml": YAML,
+	".yml":  YAML,
+}
+
+// ForFilename returns the Language of the file, based on its extension,
+// or nil if the language isn't supported
+func ForFilename(filename string) *Language {
+	return extensions[strings.ToLower(filepath.Ext(filename))]
+}
@@ -0,0 +1,192 @@
+// Package tokenizer finds the comments and string literals in source code,
+// so that rules can be limited to the human-readable parts of a file.
+// It is not a full lexer for any language, and only understands enough syntax to
+// find where comments and strings begin and end.
+package tokenizer
+
+import "strings"
+
+// Kind is the kind of a Region
+type Kind int
+
+const (
+	// Comment is a line or block comment
+	Comment Kind = iota + 1
+	// String is a string literal
+	String
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Comment:
+		return "comment"
+	case String:
+		return "string"
+	}
+	return "code"
+}
+
+// Region is a comment or string in a line, from the byte offset Start to End (exclusive).
+// Regions include their delimiters, such as the quotes of a string.
+type Region struct {
+	Start int
+	End   int
+	Kind  Kind
+}
+
+// Delimiter is the syntax that opens and closes a block comment or string
+type Delimiter struct {
+	Open  string
+	Close string
+	// Escape denotes that a backslash escapes the next character
+	Escape bool
+	// Multiline denotes that the delimiter can span multiple lines.
+	// Strings that are not multiline end at the end of the line if they are not closed.
+	Multiline bool
+}
+
+// Language is the comment and string syntax of a language
+type Language struct {
+	Name          string
+	LineComments  []string
+	BlockComments []Delimiter
+	Strings       []Delimiter
+	// CommentAfterSpace denotes that line comments only start at the beginning of a line, or after whitespace,
+	// such as # in shell, where `$#` is not a comment
+	CommentAfterSpace bool
+	// PlainScalars denotes that unquoted values are strings, such as `key: value` in YAML
+	PlainScalars bool
+}
+
+// Scanner finds the comments and strings in each line of a file.
+// Lines must be scanned in order, since comments and strings can span multiple lines.
+type Scanner struct {
+	lang *Language
+	open *Delimiter
+	kind Kind
+}
+
+// NewScanner returns a new Scanner for the language, starting at the beginning of a file
+func (l *Language) NewScanner() *Scanner {
+	return &Scanner{lang: l}
+}
+
+// Line returns the comments and strings in the next line of the file
+func (s *Scanner) Line(text string) []Region {
+	var regions []Region
+	start := 0
+
+	for i := 0; i < len(text); {
+		if s.open != nil {
+			// inside a block comment or string, which may have started on a previous line
+			if s.open.Escape && text[i] == '\\' {
+				i += 2
+				continue
+			}
+			if strings.HasPrefix(text[i:], s.open.Close) {
+				i += len(s.open.Close)
+				regions = append(regions, Region{Start: start, End: i, Kind: s.kind})
+				s.open = nil
+				continue
+			}
+			i++
+			continue
+		}
+
+		if s.isLineComment(text, i) {
+			return append(regions, Region{Start: i, End: len(text), Kind: Comment})
+		}
+		if d := matchDelimiter(s.lang.BlockComments, text, i); d != nil {
+			s.open, s.kind, start = d, Comment, i
+			i += len(d.Open)
+			continue
+		}
+		if d := matchDelimiter(s.lang.Strings, text, i); d != nil {
+			s.open, s.kind, start = d, String, i
+			i += len(d.Open)
+			continue
+		}
+		if s.lang.PlainScalars {
+			if end := plainScalarEnd(text, i); end > i {
+				regions = append(regions, Region{Start: i, End: end, Kind: String})
+				i = end
+				continue
+			}
+		}
+		i++
+	}
+
+	if s.open != nil {
+		regions = append(regions, Region{Start: start, End: len(text), Kind: s.kind})
+		if s.kind == String && !s.open.Multiline {
+			s.open = nil
+		}
+	}
+
+	return regions
+}
+
+func (s *Scanner) isLineComment(text string, i int) bool {
+	if s.lang.CommentAfterSpace && i > 0 && text[i-1] != ' ' && text[i-1] != '\t' {
+		return false
+	}
+	for _, c := range s.lang.LineComments {
+		if strings.HasPrefix(text[i:], c) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchDelimiter(delimiters []Delimiter, text string, i int) *Delimiter {
+	for j := range delimiters {
+		if strings.HasPrefix(text[i:], delimiters[j].Open) {
+			return &delimiters[j]
+		}
+	}
+	return nil
+}
+
+// plainScalarEnd returns the end of an unquoted value starting at index i, such as the value in `key: value`
+// or `- value`, or -1 if there isn't a value at i
+func plainScalarEnd(text string, i int) int {
+	// values are always separated from the key or list item by whitespace
+	if i == 0 || (text[i-1] != ' ' && text[i-1] != '\t') {
+		return -1
+	}
+	if strings.ContainsRune(" \t\"'#|>&*!{}[],", rune(text[i])) {
+		return -1
+	}
+
+	prefix := strings.TrimRight(text[:i], " \t")
+	if !strings.HasSuffix(prefix, ":") && strings.Trim(prefix, "- \t") != "" {
+		return -1
+	}
+	if strings.TrimSpace(prefix) == "" {
+		// a key at the start of a line
+		return -1
+	}
+
+	end := len(text)
+	if idx := strings.Index(text[i:], " #"); idx >= 0 {
+		end = i + idx
+	}
+	end = len(strings.TrimRight(text[:end], " \t"))
+
+	if value := text[i:end]; strings.Contains(value, ": ") || strings.HasSuffix(value, ":") {
+		// a key in a list item, such as `- key: value`
+		return -1
+	}
+	return end
+}
+
+// Within returns the kind of the region that contains the text from start to end,
+// and false if the text is not entirely within a comment or string
+func Within(regions []Region, start, end int) (Kind, bool) {
+	for _, r := range regions {
+		if r.Start <= start && end <= r.End {
+			return r.Kind, true
+		}
+	}
+	return 0, false
+}
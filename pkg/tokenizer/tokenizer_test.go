@@ -0,0 +1,170 @@
+package tokenizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scan returns the text of each region in each line, prefixed by its kind
+func scan(lang *Language, content string) [][]string {
+	s := lang.NewScanner()
+	var lines [][]string
+	for _, line := range strings.Split(content, "\n") {
+		var regions []string
+		for _, r := range s.Line(line) {
+			regions = append(regions, r.Kind.String()+":"+line[r.Start:r.End])
+		}
+		lines = append(lines, regions)
+	}
+	return lines
+}
+
+func TestScanner_Line(t *testing.T) {
+	tests := []struct {
+		desc     string
+		lang     *Language
+		content  string
+		expected [][]string
+	}{
+		{
+			desc:    "go",
+			lang:    Go,
+			content: "x := \"a \\\"quoted\\\" string\" // a comment\nr := '\\'' /* block */ + `raw",
+			expected: [][]string{
+				{`string:"a \"quoted\" string"`, "comment:// a comment"},
+				{`string:'\''`, "comment:/* block */", "string:`raw"},
+			},
+		},
+		{
+			desc:    "go multiline",
+			lang:    Go,
+			content: "/* a\nblock */ x := `raw\nstring` // comment\nslaveof()",
+			expected: [][]string{
+				{"comment:/* a"},
+				{"comment:block */", "string:`raw"},
+				{"string:string`", "comment:// comment"},
+				nil,
+			},
+		},
+		{
+			desc:    "go unterminated string",
+			lang:    Go,
+			content: "x := \"not closed\ny := 1",
+			expected: [][]string{
+				{`string:"not closed`},
+				nil,
+			},
+		},
+		{
+			desc:    "python",
+			lang:    Python,
+			content: "def f(): # comment\n    \"\"\"docstring\n    # not a comment\n    \"\"\"\n    return 'it\\'s' + \"#\"",
+			expected: [][]string{
+				{"comment:# comment"},
+				{`string:"""docstring`},
+				{"string:    # not a comment"},
+				{`string:    """`},
+				{`string:'it\'s'`, `string:"#"`},
+			},
+		},
+		{
+			desc:    "javascript",
+			lang:    JavaScript,
+			content: "const s = `template\n${x}` // comment\nconst url = 'http://example.com' /* c */",
+			expected: [][]string{
+				{"string:`template"},
+				{"string:${x}`", "comment:// comment"},
+				{"string:'http://example.com'", "comment:/* c */"},
+			},
+		},
+		{
+			desc:    "java",
+			lang:    Java,
+			content: "String s = \"\"\"\n  text block\n  \"\"\"; // comment",
+			expected: [][]string{
+				{`string:"""`},
+				{"string:  text block"},
+				{`string:  """`, "comment:// comment"},
+			},
+		},
+		{
+			desc:    "c",
+			lang:    C,
+			content: "#include <stdio.h>\nprintf(\"%s\\n\", s); // comment\nchar c = '\"';",
+			expected: [][]string{
+				nil,
+				{`string:"%s\n"`, "comment:// comment"},
+				{`string:'"'`},
+			},
+		},
+		{
+			desc:    "shell",
+			lang:    Shell,
+			content: "# comment\necho \"$# args\" 'single # quoted' # trailing\necho ${#array[@]}",
+			expected: [][]string{
+				{"comment:# comment"},
+				{`string:"$# args"`, "string:'single # quoted'", "comment:# trailing"},
+				nil,
+			},
+		},
+		{
+			desc:    "yaml",
+			lang:    YAML,
+			content: "# comment\nkey: a plain value # comment\nquoted: \"a # string\"\nlist:\n  - item\n  - key: value\nurl: http://example.com#anchor",
+			expected: [][]string{
+				{"comment:# comment"},
+				{"string:a plain value", "comment:# comment"},
+				{`string:"a # string"`},
+				nil,
+				{"string:item"},
+				{"string:value"},
+				{"string:http://example.com#anchor"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scan(tt.lang, tt.content))
+		})
+	}
+}
+
+func TestForFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected *Language
+	}{
+		{"main.go", Go},
+		{"pkg/script.PY", Python},
+		{"app.tsx", JavaScript},
+		{"Main.java", Java},
+		{"lib.hpp", C},
+		{"build.sh", Shell},
+		{".langcheck.yml", YAML},
+		{"README.md", nil},
+		{"Makefile", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ForFilename(tt.filename), tt.filename)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	regions := []Region{{Start: 2, End: 10, Kind: String}, {Start: 12, End: 20, Kind: Comment}}
+
+	kind, ok := Within(regions, 2, 10)
+	assert.True(t, ok)
+	assert.Equal(t, String, kind)
+
+	kind, ok = Within(regions, 14, 16)
+	assert.True(t, ok)
+	assert.Equal(t, Comment, kind)
+
+	_, ok = Within(regions, 8, 14)
+	assert.False(t, ok)
+
+	_, ok = Within(regions, 0, 1)
+	assert.False(t, ok)
+}